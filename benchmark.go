@@ -0,0 +1,234 @@
+package wordle
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// A BenchmarkResult is how the solver did across many answers.
+type BenchmarkResult struct {
+	// Guesses holds the number of guesses needed to solve each answer.
+	Guesses map[string]int
+
+	// Distribution holds the number of answers solved in each number of guesses.
+	Distribution map[int]int
+
+	// Mean is the average number of guesses needed to solve an answer.
+	Mean float64
+}
+
+// Benchmark solves a game for each of answers with the given options, and returns how many guesses each needed. Each
+// answer must be in the dictionary of the options. Nothing is printed, regardless of Verbose.
+//
+// Every game needs its own guesses to be calculated, so benchmarking many answers takes a long time for large
+// dictionaries.
+func Benchmark(options GameOptions, answers []string) (BenchmarkResult, error) {
+	result := BenchmarkResult{
+		Guesses:      make(map[string]int, len(answers)),
+		Distribution: map[int]int{},
+	}
+
+	if len(answers) == 0 {
+		return result, nil
+	}
+
+	source := options.dictionarySource()
+
+	total := 0
+	for _, answer := range answers {
+		if !source.Contains(answer) {
+			return BenchmarkResult{}, fmt.Errorf("answer %v isn't in the dictionary", answer)
+		}
+
+		options.Answer = answer
+
+		g, err := NewGame(options)
+		if err != nil {
+			return BenchmarkResult{}, err
+		}
+
+		guesses := g.solve().Guesses
+
+		result.Guesses[answer] = guesses
+		result.Distribution[guesses]++
+		total += guesses
+	}
+
+	result.Mean = float64(total) / float64(len(answers))
+
+	return result, nil
+}
+
+// HardestAnswers solves a game for every word in the dictionary of the options (see SolveBatch), and returns the n
+// answers which needed the most guesses along with how many they needed, hardest first. Answers needing the same number
+// of guesses are in alphabetical order. The games are solved in parallel, using every CPU. Like SolveBatch, it panics
+// if the options can't be used to play a game.
+//
+// Every word in the dictionary is solved, so it takes a long time for large dictionaries.
+func HardestAnswers(options GameOptions, n int) []struct {
+	Answer  string
+	Guesses int
+} {
+	answers := options.dictionarySource().Words()
+	results := SolveBatch(options, answers, runtime.NumCPU())
+
+	hardest := make([]struct {
+		Answer  string
+		Guesses int
+	}, len(answers))
+	for i, result := range results {
+		hardest[i].Answer, hardest[i].Guesses = answers[i], result.Guesses
+	}
+
+	sort.Slice(hardest, func(i, j int) bool {
+		if hardest[i].Guesses != hardest[j].Guesses {
+			return hardest[i].Guesses > hardest[j].Guesses
+		}
+
+		return hardest[i].Answer < hardest[j].Answer
+	})
+
+	if len(hardest) > n {
+		hardest = hardest[:n]
+	}
+
+	return hardest
+}
+
+// SolveBatch solves a game for each of answers with the given options, running up to parallelism games at once, and
+// returns their results in the same order as answers. Each answer must be in the dictionary of the options, otherwise
+// it panics before solving any of them, so the panic can be recovered from by the caller. Nothing is printed,
+// regardless of Verbose.
+//
+// Rather than every game using the shared worker pool, parallelism is a budget of workers split between the games
+// being solved, so solving many games at once doesn't oversubscribe the CPUs. The results are the same as solving each
+// game on its own. A parallelism less than 1 is treated as 1.
+//
+// A *rand.Rand can't be shared between goroutines, so if the options have Rand set, each game gets its own instead,
+// seeded from Rand in the order of answers. The results are then the same for the same seed however many games run at
+// once. Callbacks in the options, like OnAnswer, may be called from multiple goroutines at once.
+func SolveBatch(options GameOptions, answers []string, parallelism int) []GameResult {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	// The games are played on other goroutines, where panicking would crash the program, so bad options are checked
+	// here instead.
+	checked := options
+	checked.Answer = ""
+	g, err := NewGame(checked)
+	if err != nil {
+		panic(fmt.Sprintf("can't solve: %v", err))
+	}
+
+	for _, answer := range answers {
+		if err := checkAnswer(answer, g.dictionary, g.guessOnly); err != nil {
+			panic(fmt.Sprintf("can't solve %v: %v", answer, err))
+		}
+	}
+
+	var seeds []int64
+	if options.Rand != nil {
+		seeds = make([]int64, len(answers))
+		for i := range seeds {
+			seeds[i] = options.Rand.Int63()
+		}
+	}
+
+	games := parallelism
+	if len(answers) < games {
+		games = len(answers)
+	}
+
+	results := make([]GameResult, len(answers))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < games; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			pool := newEntropyWorkerPool(parallelism / games)
+			defer pool.close()
+
+			for index := range indices {
+				gameOptions := options
+				gameOptions.Answer = answers[index]
+				if seeds != nil {
+					gameOptions.Rand = rand.New(rand.NewSource(seeds[index]))
+				}
+
+				g, err := NewGame(gameOptions)
+				if err != nil {
+					panic(fmt.Sprintf("can't solve %v: %v", answers[index], err))
+				}
+				g.pool = pool
+
+				results[index] = g.solve()
+			}
+		}()
+	}
+
+	for index := range answers {
+		indices <- index
+	}
+	close(indices)
+
+	wg.Wait()
+
+	return results
+}
+
+// Solve plays a game with the given options against their answer, like Game.Play does as the computer player, without
+// printing anything. It never panics: bad options are returned as the error NewGame returns, and a panic while playing
+// is returned as a *PanicError.
+func Solve(options GameOptions) (result GameResult, err error) {
+	defer recoverPanic(&err)
+
+	if options.Answer == "" {
+		return GameResult{}, errors.New("no answer to solve for")
+	}
+
+	g, err := NewGame(options)
+	if err != nil {
+		return GameResult{}, err
+	}
+
+	return g.solve(), nil
+}
+
+// solve plays g like Game.Play does for a known answer, without printing anything.
+func (g *Game) solve() GameResult {
+	start := time.Now()
+
+	for len(g.dictionary) != 1 {
+		guess, _ := g.bestGuess(len(g.turns) == 0, false)
+		hint := createHint(guess, g.options.Answer)
+
+		g.apply(guess, hint)
+		if hint.solved() {
+			break
+		}
+	}
+
+	result := g.result()
+	g.observeGame(result, start)
+
+	if g.options.OnAnswer != nil {
+		g.options.OnAnswer(result.Answer)
+	}
+
+	return result
+}
+
+// Luck returns how many fewer guesses r needed than the average in baseline, e.g. the result of Benchmark. It's
+// positive if the game went better than expected, and negative if it went worse.
+func (r GameResult) Luck(baseline BenchmarkResult) float64 {
+	return baseline.Mean - float64(r.Guesses)
+}
@@ -0,0 +1,287 @@
+package wordle
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata instead of comparing against them")
+
+// The number of answers solved by TestGoldenSolves.
+const goldenAnswers = 50
+
+// goldenSample returns goldenAnswers answers spread evenly through Answers.
+func goldenSample() []string {
+	sample := make([]string, goldenAnswers)
+	for i := range sample {
+		sample[i] = Answers[i*len(Answers)/goldenAnswers]
+	}
+
+	return sample
+}
+
+// TestGoldenSolves solves a sample of answers with the default options, and compares the guesses made to those in
+// testdata/solves.golden. When the guesses change on purpose, e.g. because the strategy got better, update the golden
+// file with go test -run TestGoldenSolves -update.
+func TestGoldenSolves(t *testing.T) {
+	var lines []string
+	for _, result := range SolveBatch(GameOptions{}, goldenSample(), runtime.NumCPU()) {
+		guesses := make([]string, len(result.Turns))
+		for i, turn := range result.Turns {
+			guesses[i] = turn.Guess
+		}
+
+		lines = append(lines, fmt.Sprintf("%v: %v", result.Answer, strings.Join(guesses, " ")))
+	}
+
+	got := strings.Join(lines, "\n") + "\n"
+	path := filepath.Join("testdata", "solves.golden")
+
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantLines := strings.Split(strings.TrimSuffix(string(want), "\n"), "\n")
+	if len(wantLines) != len(lines) {
+		t.Fatalf("solved %v answers, but %v has %v", len(lines), path, len(wantLines))
+	}
+
+	for i := range lines {
+		if lines[i] != wantLines[i] {
+			t.Errorf("solved %q, want %q", lines[i], wantLines[i])
+		}
+	}
+}
+
+func TestLuck(t *testing.T) {
+	options := GameOptions{Dictionary: Answers[:200]}
+
+	baseline, err := Benchmark(options, options.Dictionary)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fastest, slowest := options.Dictionary[0], options.Dictionary[0]
+	for answer, guesses := range baseline.Guesses {
+		if guesses < baseline.Guesses[fastest] {
+			fastest = answer
+		}
+
+		if guesses > baseline.Guesses[slowest] {
+			slowest = answer
+		}
+	}
+
+	for _, test := range []struct {
+		answer string
+		lucky  bool
+	}{{fastest, true}, {slowest, false}} {
+		options.Answer = test.answer
+
+		result, err := Solve(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if luck := result.Luck(baseline); (luck > 0) != test.lucky || luck != baseline.Mean-float64(result.Guesses) {
+			t.Errorf("solving %v in %v guesses, against a mean of %v, has luck %v", test.answer, result.Guesses,
+				baseline.Mean, luck)
+		}
+	}
+}
+
+func TestFirstGuessInComputerMode(t *testing.T) {
+	defer quiet()()
+
+	options := GameOptions{Dictionary: Answers[:300], FirstGuess: "chill"}
+
+	result, err := Solve(GameOptions{Dictionary: options.Dictionary, FirstGuess: options.FirstGuess, Answer: "moist"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Turns[0].Guess != "chill" || result.Turns[len(result.Turns)-1].Guess != "moist" {
+		t.Fatalf("solving with a forced opener went %v, want chill first and moist last", result.Turns)
+	}
+
+	// Only the opener is forced: after it, every guess is the best one.
+	g, err := NewGame(GameOptions{Dictionary: options.Dictionary})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if guess, _ := g.BestGuess(); guess == "chill" {
+		t.Fatal("chill is the best opener anyway, so forcing it changes nothing")
+	}
+
+	for _, turn := range result.Turns {
+		if len(g.turns) != 0 {
+			if guess, _ := g.BestGuess(); guess != turn.Guess {
+				t.Errorf("guess %v is %v, want the best guess %v", len(g.turns)+1, turn.Guess, guess)
+			}
+		}
+
+		if _, _, err := g.Apply(turn.Guess, turn.Hint); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	forced, err := NewGame(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if guess, _ := forced.BestGuess(); guess != "chill" {
+		t.Errorf("best guess with a forced opener is %v, want chill", guess)
+	}
+
+	for _, result := range SolveBatch(options, options.Dictionary[:20], 2) {
+		if result.Turns[0].Guess != "chill" {
+			t.Errorf("%v: batch solve opened with %v, want chill", result.Answer, result.Turns[0].Guess)
+		}
+	}
+}
+
+func TestHardestAnswers(t *testing.T) {
+	options := GameOptions{Dictionary: ValidWords[:200]}
+
+	guesses := map[string]int{}
+	for _, answer := range options.Dictionary {
+		options.Answer = answer
+
+		result, err := Solve(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		guesses[answer] = result.Guesses
+	}
+	options.Answer = ""
+
+	hardest := HardestAnswers(options, 10)
+	if len(hardest) != 10 {
+		t.Fatalf("got %v hardest answers, want 10", len(hardest))
+	}
+
+	for i, h := range hardest {
+		if h.Guesses != guesses[h.Answer] {
+			t.Errorf("%v needed %v guesses, HardestAnswers says %v", h.Answer, guesses[h.Answer], h.Guesses)
+		}
+
+		if i > 0 && (h.Guesses > hardest[i-1].Guesses ||
+			(h.Guesses == hardest[i-1].Guesses && h.Answer < hardest[i-1].Answer)) {
+			t.Errorf("%v is ranked after %v", h, hardest[i-1])
+		}
+	}
+
+	// Every answer left out needed at most as many guesses as the easiest one returned, and if as many, is after it
+	// alphabetically.
+	included := map[string]bool{}
+	for _, h := range hardest {
+		included[h.Answer] = true
+	}
+
+	last := hardest[len(hardest)-1]
+	for answer, n := range guesses {
+		if !included[answer] && (n > last.Guesses || (n == last.Guesses && answer < last.Answer)) {
+			t.Errorf("%v needed %v guesses, but isn't in the hardest answers %v", answer, n, hardest)
+		}
+	}
+
+	if got := HardestAnswers(GameOptions{Dictionary: ValidWords[:5]}, 10); len(got) != 5 {
+		t.Errorf("got %v hardest answers of 5 words, want all of them", len(got))
+	}
+}
+
+// played returns the guesses and hints of turns. Unlike the other fields of Turn, they don't depend on whether the
+// opener was cached.
+func played(turns []Turn) string {
+	var b strings.Builder
+	for _, turn := range turns {
+		fmt.Fprintf(&b, "%v %v\n", turn.Guess, turn.Hint)
+	}
+
+	return b.String()
+}
+
+func TestSolveBatchMatchesSolve(t *testing.T) {
+	dictionary := ValidWords[:300]
+	answers := dictionary[:40]
+
+	want := make([]string, len(answers))
+	for i, answer := range answers {
+		result, err := Solve(GameOptions{Dictionary: dictionary, Answer: answer})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want[i] = played(result.Turns)
+	}
+
+	for _, parallelism := range []int{0, 1, 3, 8} {
+		baseline := runtime.NumGoroutine()
+
+		var mu sync.Mutex
+		most := 0
+		options := GameOptions{Dictionary: dictionary, OnProgress: func(float64) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if n := runtime.NumGoroutine(); n > most {
+				most = n
+			}
+		}}
+
+		for i, result := range SolveBatch(options, answers, parallelism) {
+			if got := played(result.Turns); got != want[i] {
+				t.Errorf("parallelism %v: %v solved in a batch as %v, alone as %v", parallelism, answers[i], got, want[i])
+			}
+		}
+
+		if most == 0 {
+			t.Fatalf("parallelism %v: OnProgress was never called", parallelism)
+		}
+
+		// Each game being solved at once has a goroutine, its pool's worker, and the goroutine collecting the
+		// worker's results.
+		games := parallelism
+		if games < 1 {
+			games = 1
+		}
+		if limit := baseline + 3*games; most > limit {
+			t.Errorf("parallelism %v: %v goroutines were running, want at most %v", parallelism, most, limit)
+		}
+	}
+}
+
+func TestSolveBatchRand(t *testing.T) {
+	dictionary := ValidWords[:300]
+
+	solve := func(parallelism int) string {
+		var b strings.Builder
+		for _, result := range SolveBatch(GameOptions{Dictionary: dictionary, Rand: rand.New(rand.NewSource(1))},
+			dictionary[:30], parallelism) {
+			b.WriteString(played(result.Turns))
+		}
+
+		return b.String()
+	}
+
+	if sequential, parallel := solve(1), solve(4); sequential != parallel {
+		t.Errorf("the same seed solved differently in parallel:\n%v\n%v", sequential, parallel)
+	}
+}
@@ -0,0 +1,116 @@
+package wordle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A cluster is a group of words which are the same except for one letter, e.g. "bills", "fills" and "hills". When many
+// possible answers are in a cluster, a guess can only rule out a few of them, so guessing becomes a coin flip.
+type cluster struct {
+	// pattern is the letters the words have in common, with an underscore for the letter they differ in, e.g. "_ills".
+	pattern string
+	words   []string
+
+	// overlap is the mean letterOverlap of each pair of words, how alike the words look.
+	overlap float64
+}
+
+// The fewest words in a cluster for it to be worth mentioning.
+const minClusterSize = 3
+
+// The most clusters printed each turn.
+const maxClustersShown = 5
+
+// clusters returns the clusters of at least minSize words in words, from largest to smallest. Clusters of the same size
+// are ordered by how alike their words look, since those are the hardest to tell apart. A word can be in more than one
+// cluster.
+func clusters(words []string, minSize int) []cluster {
+	byPattern := map[string][]string{}
+	for _, word := range words {
+		for i := 0; i < len(word); i++ {
+			pattern := word[:i] + "_" + word[i+1:]
+			byPattern[pattern] = append(byPattern[pattern], word)
+		}
+	}
+
+	var result []cluster
+	for pattern, words := range byPattern {
+		if len(words) >= minSize {
+			result = append(result, cluster{pattern: pattern, words: words, overlap: meanOverlap(words)})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if len(result[i].words) != len(result[j].words) {
+			return len(result[i].words) > len(result[j].words)
+		}
+
+		if result[i].overlap != result[j].overlap {
+			return result[i].overlap > result[j].overlap
+		}
+
+		return result[i].pattern < result[j].pattern
+	})
+
+	return result
+}
+
+// summarizeClusters returns a summary of the largest clusters in words, or an empty string if there are none.
+func summarizeClusters(words []string) string {
+	found := clusters(words, minClusterSize)
+	if len(found) > maxClustersShown {
+		found = found[:maxClustersShown]
+	}
+
+	summaries := make([]string, len(found))
+	for i, c := range found {
+		summaries[i] = fmt.Sprintf("%v (%v words: %v)", c.pattern, len(c.words), strings.Join(c.words, ", "))
+	}
+
+	return strings.Join(summaries, "; ")
+}
+
+// meanOverlap returns the mean letterOverlap of each pair of words, or 0 if there aren't any pairs.
+func meanOverlap(words []string) float64 {
+	total, pairs := 0, 0
+	for i := range words {
+		for j := i + 1; j < len(words); j++ {
+			total += letterOverlap(words[i], words[j])
+			pairs++
+		}
+	}
+
+	if pairs == 0 {
+		return 0
+	}
+
+	return float64(total) / float64(pairs)
+}
+
+// LetterOverlap returns how many distinct letters a and b have in common, regardless of where they are, e.g. 3 for
+// "bills" and "slide" (i, l and s). Possible answers which overlap a lot look alike, and probes testing the letters they
+// don't share tell them apart. See Game.BestProbe.
+func LetterOverlap(a, b string) int {
+	return letterOverlap(a, b)
+}
+
+// letterOverlap returns how many distinct letters a and b have in common, regardless of where they are, e.g. 3 for
+// "bills" and "slide" (i, l and s). Words which overlap a lot look alike, and are hard to tell apart with one guess.
+func letterOverlap(a, b string) int {
+	var inA, counted [256]bool
+	for i := 0; i < len(a); i++ {
+		inA[a[i]] = true
+	}
+
+	overlap := 0
+	for i := 0; i < len(b); i++ {
+		if inA[b[i]] && !counted[b[i]] {
+			counted[b[i]] = true
+			overlap++
+		}
+	}
+
+	return overlap
+}
@@ -0,0 +1,86 @@
+package wordle
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLetterOverlap(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"bills", "slide", 3},
+		{"crane", "crane", 5},
+		{"crane", "gloss", 0},
+		{"sissy", "sassy", 2},
+		{"eerie", "three", 2},
+		{"llama", "lolly", 1},
+	}
+
+	for _, test := range tests {
+		if got := LetterOverlap(test.a, test.b); got != test.want {
+			t.Errorf("LetterOverlap(%v, %v) = %v, want %v", test.a, test.b, got, test.want)
+		}
+
+		if got := LetterOverlap(test.b, test.a); got != test.want {
+			t.Errorf("LetterOverlap(%v, %v) = %v, want %v", test.b, test.a, got, test.want)
+		}
+	}
+}
+
+func TestClusters(t *testing.T) {
+	words := []string{"bills", "fills", "hills", "cores", "bores", "pores", "tares"}
+
+	found := clusters(words, minClusterSize)
+	if len(found) != 2 {
+		t.Fatalf("found %v clusters, want 2: %v", len(found), found)
+	}
+
+	// Both clusters have 3 words, but those of _ores share more distinct letters, so look more alike.
+	if found[0].pattern != "_ores" || found[1].pattern != "_ills" {
+		t.Errorf("clusters = %v, want _ores then _ills", found)
+	}
+}
+
+func TestSummarizeClusters(t *testing.T) {
+	words := []string{"bills", "fills", "hills", "cores", "bores", "pores", "tares", "moist"}
+	if got, want := summarizeClusters(words), "_ores (3 words: cores, bores, pores); _ills (3 words: bills, fills, hills)"; got != want {
+		t.Errorf("summarizeClusters(%v) = %q, want %q", words, got, want)
+	}
+
+	if got := summarizeClusters([]string{"bills", "fills", "cores", "tares"}); got != "" {
+		t.Errorf("summary without clusters = %q, want none", got)
+	}
+
+	var many []string
+	for _, prefix := range []string{"b", "c", "d"} {
+		for _, suffix := range []string{"ills", "ates", "olds", "ores", "ands", "ints"} {
+			many = append(many, prefix+suffix)
+		}
+	}
+
+	if got := strings.Count(summarizeClusters(many), " words: "); got != maxClustersShown {
+		t.Errorf("summary shows %v clusters, want %v", got, maxClustersShown)
+	}
+}
+
+func TestShowClusters(t *testing.T) {
+	defer quiet()()
+	Verbose = true
+
+	dictionary := []string{"bills", "fills", "hills", "cores", "bores", "pores", "moist"}
+	g, err := NewGame(GameOptions{Dictionary: dictionary, Guesses: []string{"zyzzy"}, FirstGuess: "zyzzy",
+		Answer: "moist", ShowClusters: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := captureOutput(t, func() {
+		g.Play()
+	})
+
+	if want := "(Guess #1) Clusters:   " + summarizeClusters(dictionary) + "\n"; !strings.Contains(output, want) {
+		t.Errorf("output doesn't contain %q:\n%v", want, output)
+	}
+}
@@ -1,25 +1,93 @@
 package wordle
 
-// A constraint is the combination of a word hint and a word. Words can be tested to see if they satisfy the constraint -
-// i.e. whether a word is possible given the known hint.
-type constraint struct {
+import "fmt"
+
+// A wordFilter is something words can be tested against to see if they're possible answers.
+type wordFilter interface {
+	Satisfies(word string) bool
+}
+
+// filterWords returns the subset of words which satisfy f.
+func filterWords(f wordFilter, words []string) []string {
+	var result []string
+
+	for _, word := range words {
+		if f.Satisfies(word) {
+			result = append(result, word)
+		}
+	}
+
+	return result
+}
+
+// A Constraint is the combination of a word hint and a word. Words can be tested to see if they satisfy the constraint -
+// i.e. whether a word is possible given the known hint. See NewConstraint.
+type Constraint struct {
 	hint wordHint
 	word string
 }
 
-// satisfies returns whether word meets all the constraints described by c.
-func (c constraint) satisfies(word string) bool {
+// NewConstraint returns the constraint that guessing guess resulted in hint, which uses the same format as Turn.Hint.
+// It returns an error if guess can't be a guess (see checkWord) or hint is malformed.
+func NewConstraint(guess, hint string) (Constraint, error) {
+	if err := checkWord(guess); err != nil {
+		return Constraint{}, fmt.Errorf("bad guess: %w", err)
+	}
+
+	var h wordHint
+	if err := h.fromString(hint); err != nil {
+		return Constraint{}, fmt.Errorf("bad hint: %w", err)
+	}
+
+	return Constraint{hint: h, word: guess}, nil
+}
+
+// Satisfies returns whether word meets all the constraints described by c, i.e. whether it can be the answer. Words of
+// the wrong size never do. The zero Constraint constrains nothing, so any word of the right size satisfies it.
+func (c Constraint) Satisfies(word string) bool {
 	// Using the constraint's word as the guess, and word as the answer, if the resulting hint is the same as the
 	// constraint's hint, then word satisfies the constraint. In other words, it means that word is possibly the answer.
-	return createHint(c.word, word) == c.hint
+	// If the constraint's hint has unknown letters, only the known ones have to be the same.
+	if len(word) != wordSize {
+		return false
+	}
+
+	if c.word == "" {
+		return true
+	}
+
+	hint := createHint(c.word, word)
+	return hint == c.hint || c.hint.matches(hint)
+}
+
+// Filter returns the subset of words in dictionary which satisfy c.
+func (c Constraint) Filter(dictionary []string) []string {
+	return filterWords(c, dictionary)
 }
 
-// filter returns the subset of words in dictionary which satisfy c.
-func (c constraint) filter(dictionary []string) []string {
+// filterNum returns the size of the subset of words in dictionary which satisfy c.
+func (c Constraint) filterNum(dictionary []string) int {
+	result := 0
+
+	for _, word := range dictionary {
+		if c.Satisfies(word) {
+			result += 1
+		}
+	}
+
+	return result
+}
+
+// eliminated returns up to n of the words in dictionary which don't satisfy c, in the order they appear.
+func (c Constraint) eliminated(dictionary []string, n int) []string {
 	var result []string
 
 	for _, word := range dictionary {
-		if c.satisfies(word) {
+		if len(result) == n {
+			break
+		}
+
+		if !c.Satisfies(word) {
 			result = append(result, word)
 		}
 	}
@@ -27,15 +95,50 @@ func (c constraint) filter(dictionary []string) []string {
 	return result
 }
 
-// filterNum returns the size of the subset of words in dictionary which satisfy c.
-func (c constraint) filterNum(dictionary []string) int {
-	result := 0
+// bucket groups the words in dictionary by the hint guess would produce if that word were the answer. It returns the
+// number of words in each group.
+func bucket(guess string, dictionary []string) map[wordHint]int {
+	result := map[wordHint]int{}
 
 	for _, word := range dictionary {
-		if c.satisfies(word) {
-			result += 1
-		}
+		result[createHint(guess, word)]++
 	}
 
 	return result
 }
+
+// A CountConstraint says how many times a letter is in the answer, regardless of where, e.g. exactly 2 e's. Such
+// constraints aren't revealed by Wordle hints, but can come from other variants or tools. See
+// Game.ApplyCountConstraint.
+type CountConstraint struct {
+	// Letter is the lower case letter being counted.
+	Letter byte
+
+	// Min and Max are the bounds, inclusive, on how many times Letter is in the answer.
+	Min, Max int
+}
+
+// validate returns an error if c can't be satisfied by any word.
+func (c CountConstraint) validate() error {
+	if c.Letter < 'a' || c.Letter > 'z' {
+		return fmt.Errorf("letter %q isn't a lower case letter", c.Letter)
+	}
+
+	if c.Min < 0 || c.Max > wordSize || c.Min > c.Max {
+		return fmt.Errorf("bad count bounds: %v to %v, must be within 0 to %v", c.Min, c.Max, wordSize)
+	}
+
+	return nil
+}
+
+// Satisfies returns whether word has between c.Min and c.Max copies of c.Letter.
+func (c CountConstraint) Satisfies(word string) bool {
+	count := 0
+	for i := 0; i < len(word); i++ {
+		if word[i] == c.Letter {
+			count++
+		}
+	}
+
+	return count >= c.Min && count <= c.Max
+}
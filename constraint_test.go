@@ -0,0 +1,187 @@
+package wordle
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestZeroConstraintSatisfiesAnyWord(t *testing.T) {
+	var c Constraint
+
+	for _, word := range []string{"tares", "crane", "zzzzz"} {
+		if !c.Satisfies(word) {
+			t.Errorf("zero constraint doesn't allow %v", word)
+		}
+	}
+
+	if c.Satisfies("tar") {
+		t.Errorf("zero constraint allows a word of the wrong size")
+	}
+}
+
+func TestWildcardHintFiltersLess(t *testing.T) {
+	fixed, err := NewConstraint("tares", "bbbby")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wildcard, err := NewConstraint("tares", "bbbb?")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixedWords, wildcardWords := fixed.Filter(ValidWords), wildcard.Filter(ValidWords)
+	if len(wildcardWords) <= len(fixedWords) {
+		t.Errorf("wildcard hint left %v words, fixed hint %v, want more", len(wildcardWords), len(fixedWords))
+	}
+
+	// The wildcard hint allows every word an absent, present or correct letter there does.
+	allowed := map[string]bool{}
+	for _, word := range wildcardWords {
+		allowed[word] = true
+	}
+
+	for _, hint := range []string{"bbbbb", "bbbby", "bbbbg"} {
+		c, err := NewConstraint("tares", hint)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, word := range c.Filter(ValidWords) {
+			if !allowed[word] {
+				t.Errorf("%v satisfies %v but not the wildcard hint", word, hint)
+			}
+		}
+	}
+}
+
+func BenchmarkConstraintFilter(b *testing.B) {
+	c, err := NewConstraint("tares", "bygbb")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		c.Filter(ValidWords)
+	}
+}
+
+func TestEliminated(t *testing.T) {
+	c, err := NewConstraint("tares", "bygbb")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dictionary := ValidWords[:500]
+	eliminated := c.eliminated(dictionary, 5)
+	if len(eliminated) != 5 {
+		t.Fatalf("got %v eliminated words, want 5", len(eliminated))
+	}
+
+	for _, word := range eliminated {
+		if c.Satisfies(word) {
+			t.Errorf("%v was eliminated, but satisfies the constraint", word)
+		}
+	}
+
+	if all := c.eliminated(dictionary, len(dictionary)); len(all)+c.filterNum(dictionary) != len(dictionary) {
+		t.Errorf("%v words were eliminated and %v left, out of %v", len(all), c.filterNum(dictionary), len(dictionary))
+	}
+}
+
+func TestCountConstraint(t *testing.T) {
+	exactlyTwoEs := CountConstraint{Letter: 'e', Min: 2, Max: 2}
+	if err := exactlyTwoEs.validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	filtered := filterWords(exactlyTwoEs, Answers)
+	if len(filtered) == 0 {
+		t.Fatal("no answers have exactly 2 e's")
+	}
+
+	for _, word := range filtered {
+		if strings.Count(word, "e") != 2 {
+			t.Errorf("%v doesn't have exactly 2 e's", word)
+		}
+	}
+
+	if len(filtered) == len(Answers) {
+		t.Error("every answer has exactly 2 e's")
+	}
+}
+
+func TestApplyCountConstraint(t *testing.T) {
+	g, err := NewGame(GameOptions{Dictionary: Answers})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remaining, err := g.ApplyCountConstraint(CountConstraint{Letter: 'e', Min: 2, Max: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := g.Apply("tares", createHint("tares", "geese").String()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, word := range g.dictionary {
+		if strings.Count(word, "e") != 2 {
+			t.Errorf("%v is possible, but doesn't have exactly 2 e's", word)
+		}
+	}
+
+	if len(g.dictionary) >= remaining {
+		t.Errorf("applying a hint after the count constraint didn't narrow it down further")
+	}
+
+	for _, c := range []CountConstraint{{Letter: 'E', Min: 0, Max: 1}, {Letter: 'e', Min: 3, Max: 2}} {
+		if _, err := g.ApplyCountConstraint(c); err == nil {
+			t.Errorf("ApplyCountConstraint(%+v) didn't return an error", c)
+		}
+	}
+}
+
+func TestNewConstraint(t *testing.T) {
+	c, err := NewConstraint("tares", createHint("tares", "moist").String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filtered := c.Filter(ValidWords)
+	found := false
+	for _, word := range filtered {
+		if word == "moist" {
+			found = true
+		}
+
+		if createHint("tares", word) != createHint("tares", "moist") {
+			t.Errorf("%v satisfies the constraint, but guessing tares for it gives a different hint", word)
+		}
+	}
+
+	if !found {
+		t.Error("the answer was filtered out")
+	}
+
+	if c.Satisfies("mois") || c.Satisfies("moists") {
+		t.Error("a word of the wrong size satisfied the constraint")
+	}
+
+	for _, test := range []struct {
+		guess, hint, err string
+	}{
+		{"tar1s", "bbbbb", "bad guess"},
+		{"tare", "bbbbb", "bad guess"},
+		{"tares", "bbbbx", "bad hint"},
+		{"tares", "bbbb", "bad hint"},
+	} {
+		if _, err := NewConstraint(test.guess, test.hint); err == nil || !strings.HasPrefix(err.Error(), test.err) {
+			t.Errorf("NewConstraint(%v, %v) error = %v, want %v...", test.guess, test.hint, err, test.err)
+		}
+	}
+}
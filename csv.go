@@ -0,0 +1,92 @@
+package wordle
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// The header row of the CSV written by GameResult.WriteCSV.
+var csvHeader = []string{"guess", "hint", "remaining_before", "remaining_after", "expected_entropy", "actual_entropy"}
+
+// WriteCSV writes the turns of r to w as CSV, for analysis in a spreadsheet: a header row, followed by a row for each
+// turn holding its guess, hint, the number of possible answers before and after it, its expected entropy and the
+// information it actually revealed (see InfoGained). ReadCSV reads them back.
+func (r GameResult) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, turn := range r.Turns {
+		err := writer.Write([]string{
+			turn.Guess,
+			turn.Hint,
+			strconv.Itoa(turn.RemainingBefore),
+			strconv.Itoa(turn.RemainingAfter),
+			strconv.FormatFloat(turn.Entropy, 'g', -1, 64),
+			strconv.FormatFloat(InfoGained(turn.RemainingBefore, turn.RemainingAfter), 'g', -1, 64),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadCSV reads the turns written by GameResult.WriteCSV from r. The actual entropy column is ignored, since it's
+// derived from the others.
+//
+// Errors include the line number of the malformed row.
+func ReadCSV(r io.Reader) ([]Turn, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(csvHeader)
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("missing header")
+	}
+
+	var turns []Turn
+	for i, row := range rows[1:] {
+		turn, err := parseCSVTurn(row)
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %w", i+2, err)
+		}
+
+		turns = append(turns, turn)
+	}
+
+	return turns, nil
+}
+
+// parseCSVTurn parses a single row of the CSV written by GameResult.WriteCSV.
+func parseCSVTurn(row []string) (Turn, error) {
+	turn, err := parseTurn(row[0] + " " + row[1])
+	if err != nil {
+		return Turn{}, err
+	}
+
+	if turn.RemainingBefore, err = strconv.Atoi(row[2]); err != nil {
+		return Turn{}, fmt.Errorf("bad remaining before: %w", err)
+	}
+
+	if turn.RemainingAfter, err = strconv.Atoi(row[3]); err != nil {
+		return Turn{}, fmt.Errorf("bad remaining after: %w", err)
+	}
+
+	if turn.Entropy, err = strconv.ParseFloat(row[4], 64); err != nil {
+		return Turn{}, fmt.Errorf("bad expected entropy: %w", err)
+	}
+
+	return turn, nil
+}
@@ -0,0 +1,65 @@
+package wordle
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	result, err := Solve(GameOptions{Dictionary: Answers[:500], Answer: "moist"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Turns) < 2 {
+		t.Fatalf("solved in %v guesses, want a game of several turns", result.Guesses)
+	}
+
+	var buf bytes.Buffer
+	if err := result.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if header := strings.SplitN(buf.String(), "\n", 2)[0]; header != strings.Join(csvHeader, ",") {
+		t.Errorf("header is %q, want %q", header, strings.Join(csvHeader, ","))
+	}
+
+	turns, err := ReadCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(turns) != len(result.Turns) {
+		t.Fatalf("read %v turns, wrote %v", len(turns), len(result.Turns))
+	}
+
+	for i, turn := range turns {
+		want := result.Turns[i]
+		if turn.Guess != want.Guess || turn.Hint != want.Hint || turn.RemainingBefore != want.RemainingBefore ||
+			turn.RemainingAfter != want.RemainingAfter || turn.Entropy != want.Entropy {
+			t.Errorf("turn %v read as %+v, wrote %+v", i+1, turn, want)
+		}
+	}
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	header := strings.Join(csvHeader, ",") + "\n"
+
+	tests := []struct {
+		csv, err string
+	}{
+		{"", "missing header"},
+		{header + "tares,bygbb,100,10,5.5,3.3\ntares,bygbb,10\n", "record on line 3"},
+		{header + "tares,bygbx,100,10,5.5,3.3\n", "line 2: bad hint"},
+		{header + "tares,bygbb,100,ten,5.5,3.3\n", "line 2: bad remaining after"},
+		{header + "tares,bygbb,100,10,much,3.3\n", "line 2: bad expected entropy"},
+	}
+
+	for _, test := range tests {
+		_, err := ReadCSV(strings.NewReader(test.csv))
+		if err == nil || !strings.HasPrefix(err.Error(), test.err) {
+			t.Errorf("ReadCSV(%q) error = %v, want %v...", test.csv, err, test.err)
+		}
+	}
+}
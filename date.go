@@ -0,0 +1,30 @@
+package wordle
+
+import (
+	"fmt"
+	"time"
+)
+
+// The date of the first official Wordle, whose answer was the first word in Answers.
+var launchDate = time.Date(2021, time.June, 19, 0, 0, 0, 0, time.UTC)
+
+// AnswerForDate returns the answer of the official Wordle on the date t falls on, in t's location. Like the official
+// game, each day uses the next word in dictionary, starting from the launch date (June 19, 2021). Use Answers as the
+// dictionary to get the official answers.
+//
+// It returns an error if t is before the launch date or after the last day dictionary has words for.
+func AnswerForDate(t time.Time, dictionary []string) (string, error) {
+	year, month, day := t.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	if date.Before(launchDate) {
+		return "", fmt.Errorf("%v is before the first Wordle on %v", date.Format("2006-01-02"), launchDate.Format("2006-01-02"))
+	}
+
+	index := int(date.Sub(launchDate).Hours() / 24)
+	if index >= len(dictionary) {
+		return "", fmt.Errorf("%v is after the last Wordle in the dictionary (%v days after launch, but there are only %v words)", date.Format("2006-01-02"), index, len(dictionary))
+	}
+
+	return dictionary[index], nil
+}
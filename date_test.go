@@ -0,0 +1,35 @@
+package wordle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnswerForDate(t *testing.T) {
+	tests := []struct {
+		date time.Time
+		want string
+	}{
+		{launchDate, "cigar"},
+		{time.Date(2021, time.June, 20, 23, 59, 0, 0, time.UTC), "rebut"},
+		{time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC), "rebus"},
+		{time.Date(2022, time.February, 2, 0, 0, 0, 0, time.FixedZone("UTC-8", -8*60*60)), "moist"},
+	}
+
+	for _, test := range tests {
+		got, err := AnswerForDate(test.date, Answers)
+		if err != nil {
+			t.Errorf("AnswerForDate(%v) returned %v", test.date, err)
+		} else if got != test.want {
+			t.Errorf("AnswerForDate(%v) = %v, want %v", test.date, got, test.want)
+		}
+	}
+}
+
+func TestAnswerForDateOutOfRange(t *testing.T) {
+	for _, date := range []time.Time{launchDate.AddDate(0, 0, -1), launchDate.AddDate(0, 0, len(Answers))} {
+		if answer, err := AnswerForDate(date, Answers); err == nil {
+			t.Errorf("AnswerForDate(%v) = %v, want an error", date, answer)
+		}
+	}
+}
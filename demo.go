@@ -0,0 +1,71 @@
+package wordle
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+)
+
+// Demo plays a game against a random answer as the computer player and prints a short narration of it: each guess,
+// its hint as emoji squares, and the number of possible answers left. The answer and any tie breaks between guesses are
+// chosen using seed, so the same seed always plays the same game. The narration is printed regardless of Verbose,
+// and none of the usual verbose output is.
+func Demo(seed int64) GameResult {
+	r := rand.New(rand.NewSource(seed))
+	answer := RandomAnswer(r)
+
+	g, err := NewGame(GameOptions{Answer: answer, Rand: r})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Demo game %v: %v possible answers\n", seed, len(g.dictionary))
+
+	for len(g.dictionary) != 1 {
+		guess, _ := g.bestGuess(len(g.turns) == 0, false)
+		hint := createHint(guess, answer)
+
+		g.apply(guess, hint)
+		fmt.Printf("%v. %v %v %v left\n", len(g.turns), guess, hint.emojis(), len(g.dictionary))
+
+		if hint.solved() {
+			break
+		}
+	}
+
+	result := g.result()
+	fmt.Printf("Solved %v in %v guesses\n", result.Answer, result.Guesses)
+
+	return result
+}
+
+// SolveAndStream solves g, which must have a known answer, and then writes the solve to w one turn at a time with delay
+// between turns: each guess and its hint as emoji squares, followed by the answer. Calculating every guess first means
+// turns are written at a steady pace. Nothing else is printed, regardless of Verbose.
+func (g *Game) SolveAndStream(w io.Writer, delay time.Duration) error {
+	if g.options.Answer == "" {
+		return errors.New("can't solve a game without a known answer")
+	}
+
+	result := g.solve()
+
+	for i, turn := range result.Turns {
+		if i != 0 {
+			time.Sleep(delay)
+		}
+
+		var hint wordHint
+		if err := hint.fromString(turn.Hint); err != nil {
+			panic(err)
+		}
+
+		if _, err := fmt.Fprintf(w, "%v. %v %v\n", i+1, turn.Guess, hint.emojis()); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "Solved %v in %v guesses\n", result.Answer, result.Guesses)
+	return err
+}
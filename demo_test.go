@@ -0,0 +1,90 @@
+package wordle
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestDemo(t *testing.T) {
+	defer quiet()()
+	Verbose = true
+
+	var results []GameResult
+	var transcripts []string
+	for _, seed := range []int64{1, 1, 2} {
+		transcripts = append(transcripts, captureOutput(t, func() {
+			results = append(results, Demo(seed))
+		}))
+	}
+
+	if transcripts[0] != transcripts[1] || results[0].Answer != results[1].Answer {
+		t.Errorf("the same seed played different games:\n%v\n%v", transcripts[0], transcripts[1])
+	}
+
+	if results[0].Answer == results[2].Answer {
+		t.Errorf("different seeds played for the same answer %v", results[0].Answer)
+	}
+
+	for _, result := range results {
+		if last := result.Turns[len(result.Turns)-1]; last.Guess != result.Answer {
+			t.Errorf("demo for %v ended guessing %v", result.Answer, last.Guess)
+		}
+	}
+
+	// Only the narration is printed.
+	line := regexp.MustCompile(`^(Demo game \d+: \d+ possible answers|\d+\. [a-z]{5} \S+ \d+ left|Solved [a-z]{5} in \d+ guesses)$`)
+	for _, transcript := range transcripts {
+		for _, l := range strings.Split(strings.TrimSuffix(transcript, "\n"), "\n") {
+			if !line.MatchString(l) {
+				t.Errorf("unexpected line in the narration: %q", l)
+			}
+		}
+	}
+}
+
+func TestSolveAndStream(t *testing.T) {
+	defer quiet()()
+
+	options := GameOptions{Dictionary: Answers[:500], Answer: "moist"}
+
+	want, err := Solve(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := NewGame(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var streamed strings.Builder
+	if err := g.SolveAndStream(&streamed, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	var expected strings.Builder
+	for i, turn := range want.Turns {
+		var hint wordHint
+		if err := hint.fromString(turn.Hint); err != nil {
+			t.Fatal(err)
+		}
+
+		fmt.Fprintf(&expected, "%v. %v %v\n", i+1, turn.Guess, hint.emojis())
+	}
+	fmt.Fprintf(&expected, "Solved moist in %v guesses\n", want.Guesses)
+
+	if streamed.String() != expected.String() {
+		t.Errorf("streamed:\n%v\nwant:\n%v", streamed.String(), expected.String())
+	}
+
+	g, err = NewGame(GameOptions{Dictionary: Answers[:500]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.SolveAndStream(&streamed, 0); err == nil {
+		t.Error("streaming a game without a known answer didn't return an error")
+	}
+}
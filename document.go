@@ -0,0 +1,114 @@
+package wordle
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// A document is the JSON document written by GameResult.MarshalDocument.
+type document struct {
+	Answer       string          `json:"answer"`
+	Guesses      int             `json:"guesses"`
+	Won          bool            `json:"won"`
+	MaxGuesses   int             `json:"maxGuesses"`
+	PuzzleNumber int             `json:"puzzleNumber,omitempty"`
+	Confidence   float64         `json:"confidence"`
+	Options      documentOptions `json:"options"`
+	Opener       string          `json:"opener"`
+	Turns        []documentTurn  `json:"turns"`
+}
+
+// documentOptions are the options of a game which affect which guesses are made, as included in a document. Options
+// which can't be written as JSON, like GameOptions.Lambda, are left out.
+type documentOptions struct {
+	DictionarySize   int      `json:"dictionarySize"`
+	Guesses          int      `json:"guesses,omitempty"`
+	FirstGuess       string   `json:"firstGuess,omitempty"`
+	BlockedGuesses   []string `json:"blockedGuesses,omitempty"`
+	AnswersOnly      bool     `json:"answersOnly,omitempty"`
+	GuessBudget      int      `json:"guessBudget,omitempty"`
+	CandidateTopK    int      `json:"candidateTopK,omitempty"`
+	EntropyReference string   `json:"entropyReference"`
+}
+
+// A documentTurn is a Turn, as included in a document.
+type documentTurn struct {
+	Guess           string          `json:"guess"`
+	Hint            string          `json:"hint"`
+	RemainingBefore int             `json:"remainingBefore"`
+	RemainingAfter  int             `json:"remainingAfter"`
+	ExpectedEntropy float64         `json:"expectedEntropy"`
+	ActualEntropy   *float64        `json:"actualEntropy,omitempty"`
+	Gap             float64         `json:"gap"`
+	TopGuesses      []documentGuess `json:"topGuesses,omitempty"`
+	Buckets         map[string]int  `json:"buckets,omitempty"`
+}
+
+// A documentGuess is a ScoredGuess, as included in a document.
+type documentGuess struct {
+	Word    string  `json:"word"`
+	Entropy float64 `json:"entropy"`
+}
+
+// MarshalDocument returns r as a single JSON document describing the whole game, e.g. to play it back on a web page:
+// the answer, the options which affected the guesses, the opener, and for each turn its guess and hint, the best
+// guesses at the time and how the possible answers split up by hint. See Turn for the details of each turn; the
+// actual entropy of a turn is left out if it left no possible answers.
+func (r GameResult) MarshalDocument() ([]byte, error) {
+	doc := document{
+		Answer:       r.Answer,
+		Guesses:      r.Guesses,
+		Won:          r.Won(),
+		MaxGuesses:   r.MaxGuesses,
+		PuzzleNumber: r.PuzzleNumber,
+		Confidence:   r.Confidence,
+		Options: documentOptions{
+			Guesses:          len(r.options.Guesses),
+			FirstGuess:       r.options.FirstGuess,
+			AnswersOnly:      r.options.AnswersOnly,
+			GuessBudget:      r.options.GuessBudget,
+			CandidateTopK:    r.options.CandidateTopK,
+			EntropyReference: "current",
+		},
+		Turns: make([]documentTurn, len(r.Turns)),
+	}
+
+	if r.options.EntropyReference == OriginalFull {
+		doc.Options.EntropyReference = "original"
+	}
+
+	for word, blocked := range r.options.BlockedGuesses {
+		if blocked {
+			doc.Options.BlockedGuesses = append(doc.Options.BlockedGuesses, word)
+		}
+	}
+	sort.Strings(doc.Options.BlockedGuesses)
+
+	if len(r.Turns) != 0 {
+		doc.Opener = r.Turns[0].Guess
+		doc.Options.DictionarySize = r.Turns[0].RemainingBefore
+	}
+
+	for i, turn := range r.Turns {
+		doc.Turns[i] = documentTurn{
+			Guess:           turn.Guess,
+			Hint:            turn.Hint,
+			RemainingBefore: turn.RemainingBefore,
+			RemainingAfter:  turn.RemainingAfter,
+			ExpectedEntropy: turn.Entropy,
+			Gap:             turn.Gap,
+			Buckets:         turn.Buckets,
+		}
+
+		if turn.RemainingBefore != 0 && turn.RemainingAfter != 0 {
+			actual := InfoGained(turn.RemainingBefore, turn.RemainingAfter)
+			doc.Turns[i].ActualEntropy = &actual
+		}
+
+		for _, guess := range turn.TopGuesses {
+			doc.Turns[i].TopGuesses = append(doc.Turns[i].TopGuesses, documentGuess{Word: guess.Word, Entropy: guess.Score})
+		}
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
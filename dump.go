@@ -0,0 +1,45 @@
+package wordle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// dump writes the possible answers left to a file in GameOptions.DumpDir, if set, named after the number of turns
+// played so far (e.g. turn_2.txt), creating the directory if needed. If the answer is known, the file is in a
+// subdirectory named after it instead (e.g. crane/turn_2.txt), so that games solved together don't overwrite each
+// other's files. The file holds one word per line, so it can be loaded with LoadDictionary. Errors are printed rather
+// than stopping the game, since dumping is only for debugging.
+func (g *Game) dump() {
+	if g.options.DumpDir == "" {
+		return
+	}
+
+	if err := g.writeDump(); err != nil {
+		fmt.Printf("Can't dump the possible answers: %v\n", err)
+	}
+}
+
+// writeDump writes the file described by dump.
+func (g *Game) writeDump() error {
+	dir := g.options.DumpDir
+	if answer, ok := g.Answer(); ok {
+		dir = filepath.Join(dir, answer)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	var contents strings.Builder
+	for _, word := range g.dictionary {
+		contents.WriteString(word)
+		contents.WriteString("\n")
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("turn_%v.txt", len(g.turns)))
+
+	return os.WriteFile(path, []byte(contents.String()), 0644)
+}
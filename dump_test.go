@@ -0,0 +1,116 @@
+package wordle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDumpDir(t *testing.T) {
+	defer quiet()()
+
+	dir := t.TempDir()
+	dictionary := []string{"bills", "fills", "hills", "crane", "crate", "tares"}
+
+	g, err := NewGame(GameOptions{Answer: "hills", Dictionary: dictionary, DumpDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g.Play()
+
+	// After each turn played, the dump holds the possible answers left then.
+	remaining := dictionary
+	for i, turn := range g.turns {
+		var hint wordHint
+		if err := hint.fromString(turn.Hint); err != nil {
+			t.Fatal(err)
+		}
+		remaining = Constraint{hint: hint, word: turn.Guess}.Filter(remaining)
+
+		contents, err := os.ReadFile(filepath.Join(dir, "hills", fmt.Sprintf("turn_%v.txt", i+1)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(contents), strings.Join(remaining, "\n")+"\n"; got != want {
+			t.Errorf("turn %v dump = %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+func TestDumpDirMultiGame(t *testing.T) {
+	dir := t.TempDir()
+
+	m, err := NewMultiGame(GameOptions{Dictionary: []string{"bills", "fills", "hills"}, DumpDir: dir}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Apply("bills", []string{"bgggg", "ggggg"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for board, want := range []string{"fills\nhills\n", "bills\n"} {
+		contents, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("board_%v", board+1), "turn_1.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(contents) != want {
+			t.Errorf("board %v dump = %q, want %q", board+1, contents, want)
+		}
+	}
+}
+
+func TestDumpDirSolveBatch(t *testing.T) {
+	dir := t.TempDir()
+	dictionary := []string{"bills", "fills", "hills", "crane", "crate", "tares"}
+
+	results := SolveBatch(GameOptions{Dictionary: dictionary, DumpDir: dir}, dictionary, 3)
+
+	// Each game's last dump is its answer, whatever the other games dumped.
+	for _, result := range results {
+		dumps, err := os.ReadDir(filepath.Join(dir, result.Answer))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		contents, err := os.ReadFile(filepath.Join(dir, result.Answer, fmt.Sprintf("turn_%v.txt", len(dumps))))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := result.Answer + "\n"; string(contents) != want {
+			t.Errorf("last dump solving %v = %q, want %q", result.Answer, contents, want)
+		}
+	}
+}
+
+func TestDumpDirError(t *testing.T) {
+	// The dump directory can't be created, since a file is in the way.
+	path := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := NewGame(GameOptions{Answer: "hills", Dictionary: []string{"bills", "fills", "hills"}, DumpDir: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result GameResult
+	output := captureOutput(t, func() {
+		result = g.solve()
+	})
+
+	if result.Answer != "hills" {
+		t.Errorf("game which couldn't be dumped found %v, want hills", result.Answer)
+	}
+
+	if !strings.Contains(output, "Can't dump the possible answers") {
+		t.Errorf("failing to dump wasn't reported:\n%v", output)
+	}
+}
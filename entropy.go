@@ -2,37 +2,59 @@ package wordle
 
 import (
 	"math"
+	"sync"
+	"sync/atomic"
 )
 
-// An entropyWorker calculates the entropy of words for a given list of hints.
+// hintCounts holds the number of words in a dictionary which result in each hint for a guess, indexed by
+// wordHint.index. It's a column of the pattern matrix: the hint each guess results in for each possible answer.
+type hintCounts [numPossibleWordHints]int
+
+// countHints adds the number of words in dictionary which result in each hint when guessing word to counts.
+func countHints(word string, dictionary []string, counts *hintCounts) {
+	for _, answer := range dictionary {
+		counts[createHint(word, answer).index()]++
+	}
+}
+
+// An entropyWorker counts the hints a word results in for its share of a dictionary.
 type entropyWorker struct {
-	jobs      <-chan entropyWorkJob
-	result    chan<- entropyWorkResult
-	workerNum int
-	hints     []wordHint
+	jobs       <-chan entropyWorkJob
+	result     chan<- entropyWorkResult
+	workerNum  int
+	numWorkers int
 }
 
+// An entropyWorkJob is a request to calculate the entropy of word for a dictionary.
 type entropyWorkJob struct {
 	word       string
 	dictionary []string
 }
 
-// An entropyWorkResult is the result of an entropy calculation by an entropyWorker.
+// An entropyWorkResult is the result of an entropy calculation by an entropyWorker: the hint counts for its share of
+// the dictionary.
 type entropyWorkResult struct {
 	workerNum int
-	entropy   float64
+	counts    hintCounts
 }
 
+// work counts hints for each job it receives, until the pool is closed.
 func (e entropyWorker) work() {
-	for {
-		select {
-		case job := <-e.jobs:
-			e.calculateEntropy(job.word, job.dictionary)
-		}
+	for job := range e.jobs {
+		result := entropyWorkResult{workerNum: e.workerNum}
+		countHints(job.word, e.share(job.dictionary), &result.counts)
+		e.result <- result
 	}
 }
 
-// calculateEntropy calculates the entropy for the given word in the context of a dictionary of possible words using the hints configured for this worker.
+// share returns the part of dictionary this worker counts hints for. Together, the workers' shares cover the whole
+// dictionary.
+func (e entropyWorker) share(dictionary []string) []string {
+	return dictionary[len(dictionary)*e.workerNum/e.numWorkers : len(dictionary)*(e.workerNum+1)/e.numWorkers]
+}
+
+// hintEntropy calculates the entropy of a word from the hints it results in for a dictionary of possible words of the
+// given size.
 //
 // Note: this is based on https://www.youtube.com/watch?v=v68zYyaEmEA and https://en.wikipedia.org/wiki/Entropy_(information_theory).
 //
@@ -42,13 +64,13 @@ func (e entropyWorker) work() {
 // If it had an entropy of 2, guessing it would reduce the number of possible words by 4 (e.g. 100 -> 25). 3 would reduce by 8 (100 -> 12.5), and so on.
 //
 // The entropy of a word is calculated as the sum of the expected information for all possible hints it results in.
-// (An aside: the answer is unknown, so all hints are possible. Some yield 0 remaining words in the dictionary; they are incorrect hints and ignored.
-// Entropy is additive - this worker is configured to calculate a subset of the hints which it adds together before sending to the pool, which does a final addition to yield the final entropy).
+// (An aside: the answer is unknown, so all hints are possible. Some yield 0 remaining words in the dictionary; they are incorrect hints and ignored.)
 //
 // The expected information of a hint is how likely that hint is (if it's less likely it contributes less to the average)
 // multiplied by how much information it provides (if it's less likely, it provides more information, because on being correct it reduces the number of possibilities more).
 //
 // How likely a hint is defined as the number of remaining valid words after applying the hint to the dictionary, divided by the total words. If more words are left, it's more likely the answer is one of those words.
+// That's the number of words in the dictionary which result in the hint, so counting the hint of each word gives every hint's likeliness at once.
 // How much information a hint provides is defined as log2(hint likeliness), because of fancy information theory.
 //
 // For example, if the dictionary contains 2 words "bar" and "baz", the possible hints are "ggg" and "bgg" for both words (the cases where either is the answer).
@@ -59,70 +81,63 @@ func (e entropyWorker) work() {
 // actually occurred.
 //
 // Multiplying these two together, and summing across all hints, yields the entropy for a word.
-func (e entropyWorker) calculateEntropy(word string, dictionary []string) {
-	dictionarySize := float64(len(dictionary))
-
-	var entropy float64
-
-	for _, hint := range e.hints {
-		c := constraint{
-			hint: hint,
-			word: word,
-		}
-
-		remainingSize := float64(c.filterNum(dictionary))
+func hintEntropy(counts *hintCounts, dictionarySize int) float64 {
+	var result float64
 
-		if remainingSize == 0 {
+	for _, count := range counts {
+		if count == 0 {
 			continue
 		}
 
-		probability := remainingSize / dictionarySize
-		entropy += math.Log2(1/probability) * probability
+		probability := float64(count) / float64(dictionarySize)
+		result += math.Log2(1/probability) * probability
 	}
 
-	e.result <- entropyWorkResult{
-		workerNum: e.workerNum,
-		entropy:   entropy,
-	}
+	return result
 }
 
 // An entropyWorkerPool calculates the entropy of a given word using a pool of workers to maximize resource utilization.
 // Entropy is the measure used to determine quality of words.
-// The pool shards the possible hints across all of its workers, parallelizing the work.
+// The pool shards the dictionary across all of its workers, parallelizing the work. It's safe for concurrent use: the
+// workers calculate one word at a time, so concurrent calculations take turns.
 type entropyWorkerPool struct {
 	numWorkers int
 
+	// dispatch is held while the workers calculate a word, so that concurrent calculations don't collect each other's
+	// results.
+	dispatch *sync.Mutex
+
 	workers []chan entropyWorkJob
 	results chan entropyWorkResult
 	done    chan bool
+	cache   *entropyCache
 }
 
-// newEntropyWorkerPool creates an entropyWorkerPool with the configured number of workers.
+// newEntropyWorkerPool creates an entropyWorkerPool with the configured number of workers. A pool needs at least one
+// worker, so it has one if numWorkers isn't positive.
 func newEntropyWorkerPool(numWorkers int) entropyWorkerPool {
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+
 	wp := entropyWorkerPool{
 		numWorkers: numWorkers,
+		dispatch:   &sync.Mutex{},
 		workers:    make([]chan entropyWorkJob, numWorkers),
 		results:    make(chan entropyWorkResult, numWorkers),
 		done:       make(chan bool),
+		cache:      &entropyCache{},
 	}
 
-	hintsPerWorker := (numPossibleWordHints / numWorkers) + 1
-
 	for workerNum := 0; workerNum < numWorkers; workerNum++ {
-		startHint := workerNum * hintsPerWorker
-		stopHint := (workerNum + 1) * hintsPerWorker
-		if stopHint > numPossibleWordHints {
-			stopHint = numPossibleWordHints
-		}
-
 		jobChan := make(chan entropyWorkJob)
 		wp.workers[workerNum] = jobChan
 
 		worker := entropyWorker{
-			jobs:      jobChan,
-			result:    wp.results,
-			workerNum: workerNum,
-			hints:     possibleWordHints[startHint:stopHint],
+			jobs:       jobChan,
+			result:     wp.results,
+			workerNum:  workerNum,
+			numWorkers: numWorkers,
 		}
 
 		go worker.work()
@@ -131,34 +146,61 @@ func newEntropyWorkerPool(numWorkers int) entropyWorkerPool {
 	return wp
 }
 
-// collectWorkerResults waits for all workers to complete and then aggregates their results into a final entropy
-// result. It does so in a deterministic manner so that race conditions between worker completion and floating point math
-// don't cause non-deterministic results.
-func (e entropyWorkerPool) collectWorkerResults() float64 {
-	results := make([]float64, e.numWorkers)
+// collectWorkerResults waits for all workers to complete and then adds up their hint counts.
+func (e entropyWorkerPool) collectWorkerResults() *hintCounts {
+	var counts hintCounts
 
 	go func() {
 		for workerNum := 0; workerNum < e.numWorkers; workerNum++ {
 			result := <-e.results
-			results[result.workerNum] = result.entropy
+			for i, count := range result.counts {
+				counts[i] += count
+			}
 		}
 		e.done <- true
 	}()
 
 	<-e.done
 
-	// The entropy of the word is the sum of the entropy of all the workers.
-	sum := 0.0
-	for workerNum := 0; workerNum < len(results); workerNum++ {
-		sum += results[workerNum]
+	return &counts
+}
+
+// serialThreshold is the dictionary size below which the entropy of a word is calculated serially instead of by the
+// pool's workers, because handing the calculation off to the workers and waiting for them costs more than it saves.
+//
+// Counting the hint of a dictionary word costs roughly 70ns, while handing a word to the workers and collecting the
+// results costs roughly 3µs per worker. So only dictionaries of a few hundred words or more are worth sharing
+// out.
+const serialThreshold = 256
+
+// calculateEntropy starts the pool's workers on the task of calculating the entropy for the given word in context of
+// the given dictionary. The version must change whenever the contents of the dictionary do - see newDictionaryVersion.
+//
+// Small dictionaries are calculated serially instead - see serialThreshold. The result is the same either way: the
+// hint counts are the same however the dictionary is shared out, and entropy only depends on them.
+func (e entropyWorkerPool) calculateEntropy(word string, dictionary []string, version uint64) float64 {
+	if result, ok := e.cache.get(word, version); ok {
+		return result
 	}
 
-	return sum
+	var result float64
+	if len(dictionary) < serialThreshold {
+		result = calculateEntropySerially(word, dictionary)
+	} else {
+		result = e.calculateEntropyInParallel(word, dictionary)
+	}
+
+	e.cache.set(word, version, result)
+
+	return result
 }
 
-// calculateEntropy starts the pool's workers on the task of calculating the entropy for the given word in context of
-// the given dictionary.
-func (e entropyWorkerPool) calculateEntropy(word string, dictionary []string) float64 {
+// calculateEntropyInParallel calculates the entropy for the given word in context of the given dictionary by sharing
+// the dictionary out to the pool's workers.
+func (e entropyWorkerPool) calculateEntropyInParallel(word string, dictionary []string) float64 {
+	e.dispatch.Lock()
+	defer e.dispatch.Unlock()
+
 	// start workers
 	for _, worker := range e.workers {
 		worker <- entropyWorkJob{
@@ -167,17 +209,177 @@ func (e entropyWorkerPool) calculateEntropy(word string, dictionary []string) fl
 		}
 	}
 
-	return e.collectWorkerResults()
+	return hintEntropy(e.collectWorkerResults(), len(dictionary))
 }
 
-var (
-	possibleWordHints    = allPossibleWordHints()
-	numPossibleWordHints = len(possibleWordHints)
-)
+// calculateEntropySerially calculates the entropy for the given word in context of the given dictionary on the calling
+// goroutine.
+func calculateEntropySerially(word string, dictionary []string) float64 {
+	var counts hintCounts
+	countHints(word, dictionary, &counts)
+
+	return hintEntropy(&counts, len(dictionary))
+}
+
+// calculateEntropies calculates the entropy of each of words in context of the given dictionary. Rather than sharing
+// the dictionary of each word out to the workers, the words are spread across goroutines, each calculating the entropy
+// of its words serially like calculateEntropySerially. This avoids waiting for each word to be finished before starting
+// the next. The results are the same as calculateEntropy's.
+func (e entropyWorkerPool) calculateEntropies(words []string, dictionary []string, version uint64) []float64 {
+	results := make([]float64, len(words))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < e.numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for index := range indices {
+				word := words[index]
+
+				result, ok := e.cache.get(word, version)
+				if !ok {
+					result = calculateEntropySerially(word, dictionary)
+					e.cache.set(word, version, result)
+				}
+
+				results[index] = result
+			}
+		}()
+	}
+
+	for index := range words {
+		indices <- index
+	}
+	close(indices)
+
+	wg.Wait()
+
+	return results
+}
+
+// close stops the pool's workers. The pool can't be used afterwards.
+func (e entropyWorkerPool) close() {
+	for _, worker := range e.workers {
+		close(worker)
+	}
+}
+
+// cacheStats returns the number of cache hits and the total number of cache lookups made by the pool.
+func (e entropyWorkerPool) cacheStats() (hits, lookups uint64) {
+	return atomic.LoadUint64(&e.cache.hits), atomic.LoadUint64(&e.cache.lookups)
+}
+
+// The last dictionary version handed out by newDictionaryVersion.
+var dictionaryVersion uint64
+
+// newDictionaryVersion returns a number identifying a dictionary, which is different from every other number it
+// returns. Dictionaries are large, so rather than comparing them to tell whether cached work still applies, the version
+// of the dictionary is compared.
+func newDictionaryVersion() uint64 {
+	return atomic.AddUint64(&dictionaryVersion, 1)
+}
+
+// The maximum number of entries an entropyCache holds. Once full, new results aren't cached.
+const maxEntropyCacheSize = 1 << 16
+
+// An entropyCache caches the entropy of words for a single version of a dictionary. It's used to avoid recalculating
+// the entropy of a word when it's scored against the same dictionary more than once. It's safe for concurrent use.
+//
+// It used to cache the size of the dictionary left by each (guess, hint) pair instead, but entropy is now calculated by
+// counting the hint each answer gives in a single pass over the dictionary, so there aren't any filter sizes left to
+// reuse. What does recur is scoring the same word against the same dictionary: none of the first guess pass is a hit,
+// but lookahead through ExpectedGuesses and BuildDecisionTree reaches the same dictionaries through different hints.
+type entropyCache struct {
+	mu        sync.Mutex
+	version   uint64
+	entropies map[string]float64
+
+	// Accessed atomically, so that they can be read without locking.
+	hits, lookups uint64
+}
+
+// get returns the cached entropy of word for the given version of the dictionary, if there is one.
+func (c *entropyCache) get(word string, version uint64) (float64, bool) {
+	atomic.AddUint64(&c.lookups, 1)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.version != version {
+		return 0, false
+	}
+
+	result, ok := c.entropies[word]
+	if ok {
+		atomic.AddUint64(&c.hits, 1)
+	}
+
+	return result, ok
+}
+
+// set caches the entropy of word for the given version of the dictionary, clearing the cache if it's for a different
+// version.
+func (c *entropyCache) set(word string, version uint64, entropy float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entropies == nil || c.version != version {
+		c.version = version
+		c.entropies = map[string]float64{}
+	}
+
+	if len(c.entropies) < maxEntropyCacheSize {
+		c.entropies[word] = entropy
+	}
+}
+
+// InfoGained returns the information, in bits, gained by narrowing down the possible answers from before words to after
+// words. This is the actual entropy of a guess, as opposed to the expected entropy calculated before making it.
+//
+// If after is zero, no answer is possible any more and the result is +Inf.
+func InfoGained(before, after int) float64 {
+	if after == 0 {
+		return math.Inf(1)
+	}
+
+	return math.Log2(float64(before) / float64(after))
+}
+
+// InfoLowerBound returns the information-theoretic minimum number of guesses needed to narrow dictionarySize possible
+// answers down to one: ceil(log_243(dictionarySize)). Each guess results in one of numPossibleWordHints hints, so it can
+// split the possible answers into at most that many groups. It's 0 if there's at most one possible answer.
+func InfoLowerBound(dictionarySize int) int {
+	guesses := 0
+	for distinguishable := 1; distinguishable < dictionarySize; guesses++ {
+		// Any more can't make a difference, and would eventually overflow.
+		if distinguishable > dictionarySize/numPossibleWordHints {
+			guesses++
+			break
+		}
+
+		distinguishable *= numPossibleWordHints
+	}
+
+	return guesses
+}
+
+// wordsEliminated converts entropy into the number of words out of size a guess eliminates on average. An entropy of e
+// reduces the number of possible words by a factor of 2**e, so size/2**e words are left.
+func wordsEliminated(entropy float64, size int) float64 {
+	return float64(size) - float64(size)/math.Pow(2, entropy)
+}
+
+// The number of hints a word can result in: 3**wordSize, one for each combination of the three letter hints.
+const numPossibleWordHints = 3 * 3 * 3 * 3 * 3
+
+var possibleWordHints = allPossibleWordHints()
 
-// allPossibleWordHints returns all 3**5 possible permutations of the three possible letter combined for 5 words
+// allPossibleWordHints returns all 3**5 possible permutations of the three possible letter combined for 5 words, in
+// the order of wordHint.index.
 func allPossibleWordHints() []wordHint {
-	result := make([]wordHint, int(math.Pow(3, wordSize)))
+	result := make([]wordHint, numPossibleWordHints)
 
 	var current wordHint
 
@@ -185,8 +387,8 @@ func allPossibleWordHints() []wordHint {
 		result[i] = current
 
 		index := 0
-		for current[index] == correct {
-			current[index] = absent
+		for current[index] == Correct {
+			current[index] = Absent
 			index++
 
 			if index == wordSize {
@@ -0,0 +1,247 @@
+package wordle
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestInfoGained(t *testing.T) {
+	tests := []struct {
+		before, after int
+		want          float64
+	}{
+		{100, 25, 2},
+		{2315, 1, math.Log2(2315)},
+		{50, 50, 0},
+		{50, 0, math.Inf(1)},
+	}
+
+	for _, test := range tests {
+		if got := InfoGained(test.before, test.after); got != test.want {
+			t.Errorf("InfoGained(%v, %v) = %v, want %v", test.before, test.after, got, test.want)
+		}
+	}
+}
+
+func TestInfoLowerBound(t *testing.T) {
+	tests := []struct {
+		size, want int
+	}{
+		{0, 0},
+		{1, 0},
+		{2, 1},
+		{243, 1},
+		{244, 2},
+		{len(ValidWords), 2},
+		{243 * 243, 2},
+		{243*243 + 1, 3},
+		{math.MaxInt64, 8},
+	}
+
+	for _, test := range tests {
+		if got := InfoLowerBound(test.size); got != test.want {
+			t.Errorf("InfoLowerBound(%v) = %v, want %v", test.size, got, test.want)
+		}
+	}
+}
+
+func TestEntropyCache(t *testing.T) {
+	pool := newEntropyWorkerPool(2)
+	defer pool.close()
+
+	dictionary := ValidWords[:1000]
+	version := newDictionaryVersion()
+
+	for _, word := range []string{"tares", "crane", "fuzzy"} {
+		uncached := calculateEntropySerially(word, dictionary)
+
+		for i := 0; i < 2; i++ {
+			if got := pool.calculateEntropy(word, dictionary, version); got != uncached {
+				t.Errorf("calculation %v of the entropy of %v = %v, want %v", i+1, word, got, uncached)
+			}
+		}
+	}
+
+	if hits, lookups := pool.cacheStats(); hits != 3 || lookups != 6 {
+		t.Errorf("cache hits, lookups = %v, %v, want 3, 6", hits, lookups)
+	}
+
+	// A different version of the dictionary has different entropies.
+	smaller := dictionary[:500]
+	if got, want := pool.calculateEntropy("tares", smaller, newDictionaryVersion()),
+		calculateEntropySerially("tares", smaller); got != want {
+		t.Errorf("entropy of tares for a new version = %v, want %v", got, want)
+	}
+}
+
+func BenchmarkEntropyCached(b *testing.B) {
+	b.ReportAllocs()
+	version := newDictionaryVersion()
+
+	for i := 0; i < b.N; i++ {
+		workerPool.calculateEntropy("tares", ValidWords, version)
+	}
+}
+
+func BenchmarkEntropyUncached(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		workerPool.calculateEntropy("tares", ValidWords, newDictionaryVersion())
+	}
+}
+
+func TestSerialEntropyMatchesPool(t *testing.T) {
+	pool := newEntropyWorkerPool(4)
+	defer pool.close()
+
+	for _, dictionary := range [][]string{ValidWords[:1000], endgameDictionary} {
+		for _, word := range []string{"tares", "crane", "fuzzy", "eerie"} {
+			pooled := pool.calculateEntropyInParallel(word, dictionary)
+			if serial := calculateEntropySerially(word, dictionary); math.Abs(pooled-serial) > 1e-12 {
+				t.Errorf("entropy of %v for %v words is %v calculated by the pool, %v serially", word, len(dictionary),
+					pooled, serial)
+			}
+		}
+	}
+}
+
+// The dictionary of the endgame, where few possible answers are left.
+var endgameDictionary = ValidWords[:10]
+
+func BenchmarkEntropySerial(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		calculateEntropySerially("tares", endgameDictionary)
+	}
+}
+
+func BenchmarkEntropyPooled(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		workerPool.calculateEntropyInParallel("tares", endgameDictionary)
+	}
+}
+
+func TestWordsEliminated(t *testing.T) {
+	tests := []struct {
+		entropy float64
+		size    int
+		want    float64
+	}{
+		{0, 100, 0},
+		{1, 100, 50},
+		{2, 100, 75},
+		{3, 100, 87.5},
+		{math.Log2(240), 240, 239},
+	}
+
+	for _, test := range tests {
+		if got := wordsEliminated(test.entropy, test.size); math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("wordsEliminated(%v, %v) = %v, want %v", test.entropy, test.size, got, test.want)
+		}
+	}
+}
+
+// entropyByFiltering calculates the entropy of guessing word for dictionary the way it was before hints were counted:
+// by filtering dictionary with every possible hint.
+func entropyByFiltering(word string, dictionary []string) float64 {
+	var counts hintCounts
+	for i, hint := range possibleWordHints {
+		counts[i] = Constraint{hint: hint, word: word}.filterNum(dictionary)
+	}
+
+	return hintEntropy(&counts, len(dictionary))
+}
+
+// The dictionary of the midgame, used to compare ways of calculating entropy.
+var midgameDictionary = ValidWords[:500]
+
+func TestCountingHintsMatchesFiltering(t *testing.T) {
+	for _, word := range []string{"tares", "crane", "fuzzy", "eerie"} {
+		counted := calculateEntropySerially(word, midgameDictionary)
+		if filtered := entropyByFiltering(word, midgameDictionary); math.Abs(counted-filtered) > 1e-12 {
+			t.Errorf("entropy of %v is %v counting hints, %v filtering", word, counted, filtered)
+		}
+	}
+}
+
+func BenchmarkEntropyCountingHints(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		calculateEntropySerially("tares", midgameDictionary)
+	}
+}
+
+func BenchmarkEntropyFiltering(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		entropyByFiltering("tares", midgameDictionary)
+	}
+}
+
+func TestPoolWithoutWorkers(t *testing.T) {
+	for _, numWorkers := range []int{0, -3} {
+		pool := newEntropyWorkerPool(numWorkers)
+		if pool.numWorkers != 1 {
+			t.Errorf("pool created with %v workers has %v, want 1", numWorkers, pool.numWorkers)
+		}
+
+		entropy := make(chan float64, 1)
+		go func() {
+			entropy <- pool.calculateEntropyInParallel("tares", ValidWords[:1000])
+		}()
+
+		select {
+		case got := <-entropy:
+			if want := calculateEntropySerially("tares", ValidWords[:1000]); math.Abs(got-want) > 1e-12 {
+				t.Errorf("entropy calculated by a pool created with %v workers is %v, want %v", numWorkers, got, want)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("pool created with %v workers didn't calculate entropy", numWorkers)
+		}
+
+		pool.close()
+	}
+}
+
+func TestPoolConcurrentCallers(t *testing.T) {
+	pool := newEntropyWorkerPool(4)
+	defer pool.close()
+
+	words := []string{"tares", "crane", "fuzzy", "eerie", "moist", "bills", "jazzy", "audio"}
+	dictionary := ValidWords[:1000]
+
+	entropies := make([][]float64, len(words))
+
+	var wg sync.WaitGroup
+	for i := range words {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 20; j++ {
+				entropies[i] = append(entropies[i], pool.calculateEntropyInParallel(words[i], dictionary))
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, word := range words {
+		want := calculateEntropySerially(word, dictionary)
+		for _, got := range entropies[i] {
+			if math.Abs(got-want) > 1e-12 {
+				t.Errorf("entropy of %v calculated by a pool shared by %v callers is %v, want %v", word, len(words), got,
+					want)
+				break
+			}
+		}
+	}
+}
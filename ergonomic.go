@@ -0,0 +1,25 @@
+package wordle
+
+import "fmt"
+
+// englishLetterFrequency holds how often each letter, from a to z, is used in English text, as a percentage.
+var englishLetterFrequency = [26]float64{
+	8.2, 1.5, 2.8, 4.3, 12.7, 2.2, 2.0, 6.1, 7.0, 0.15, 0.77, 4.0, 2.4,
+	6.7, 7.5, 1.9, 0.095, 6.0, 6.3, 9.1, 2.8, 0.98, 2.4, 0.15, 2.0, 0.074,
+}
+
+// ErgonomicScore scores word by how easy it is to type, as the average frequency of its letters in English text: words
+// made of common letters score higher than ones needing rarely used keys like q, z or x. It's meant to be used as
+// GameOptions.Scorer, to break ties between guesses with TieScorer. It panics if word can't be a guess.
+func ErgonomicScore(word string) float64 {
+	if err := checkWord(word); err != nil {
+		panic(fmt.Sprintf("bad guess %v: %v", word, err))
+	}
+
+	var total float64
+	for i := 0; i < len(word); i++ {
+		total += englishLetterFrequency[word[i]-'a']
+	}
+
+	return total / float64(len(word))
+}
@@ -0,0 +1,53 @@
+package wordle
+
+import "testing"
+
+func TestErgonomicScore(t *testing.T) {
+	// From easiest to type to hardest.
+	words := []string{"eerie", "tares", "bills", "jazzy", "fuzzy"}
+
+	for i := 1; i < len(words); i++ {
+		if easier, harder := ErgonomicScore(words[i-1]), ErgonomicScore(words[i]); easier <= harder {
+			t.Errorf("ErgonomicScore(%v) = %v, want more than ErgonomicScore(%v) = %v", words[i-1], easier, words[i],
+				harder)
+		}
+	}
+}
+
+func TestErgonomicTieBreak(t *testing.T) {
+	defer quiet()()
+
+	bestGuess := func(dictionary []string, guess, hint string, options GameOptions) string {
+		options.Dictionary = dictionary
+
+		g, err := NewGame(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The first guess is cached once calculated, and then always used as is, so the tie is after it.
+		if _, _, err := g.Apply(guess, hint); err != nil {
+			t.Fatal(err)
+		}
+
+		best, _ := g.BestGuess()
+		return best
+	}
+
+	ergonomic := GameOptions{TiePolicy: TieScorer, Scorer: ErgonomicScore}
+
+	// Every word is tied, and only the first letter differs.
+	tied := []string{"jills", "kills", "fills", "bills", "tills"}
+	if first, easiest := bestGuess(tied, "crane", "bbbbb", GameOptions{}), bestGuess(tied, "crane", "bbbbb", ergonomic); first != "jills" || easiest != "tills" {
+		t.Errorf("best guesses of tied words are %v, and %v by ErgonomicScore, want jills and tills", first, easiest)
+	}
+
+	// Guesses almost as good as the best one are tied with it too.
+	first := bestGuess(ValidWords, "tares", "bybbb", GameOptions{})
+	ergonomic.TieTolerance = 0.3
+	easiest := bestGuess(ValidWords, "tares", "bybbb", ergonomic)
+
+	if first == easiest || ErgonomicScore(easiest) <= ErgonomicScore(first) {
+		t.Errorf("best guess with a tie tolerance is %v, want one easier to type than the best guess %v", easiest, first)
+	}
+}
@@ -0,0 +1,136 @@
+package wordle
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrWrongLength matches (using errors.Is) errors caused by a guess or hint being the wrong size. See
+	// WrongLengthError for details of the error.
+	ErrWrongLength = errors.New("wrong size")
+
+	// ErrInvalidHintChar matches (using errors.Is) errors caused by a hint containing an unexpected character. See
+	// InvalidHintCharError for details of the error.
+	ErrInvalidHintChar = errors.New("invalid hint character")
+
+	// ErrInvalidWordChar matches (using errors.Is) errors caused by a word containing something other than a lower case
+	// letter. See InvalidWordCharError for details of the error.
+	ErrInvalidWordChar = errors.New("invalid word character")
+
+	// ErrUnknownAnswer matches (using errors.Is) errors caused by an answer which isn't one of the possible answers. See
+	// UnknownAnswerError for details of the error.
+	ErrUnknownAnswer = errors.New("unknown answer")
+
+	// ErrPanicked matches (using errors.Is) errors caused by a panic while playing a game. See PanicError for details of
+	// the error.
+	ErrPanicked = errors.New("panicked")
+)
+
+// A WrongLengthError is the error for a guess or hint that's the wrong size.
+type WrongLengthError struct {
+	Expected, Got int
+}
+
+func (e *WrongLengthError) Error() string {
+	return fmt.Sprintf("wrong size: expected %v, got %v", e.Expected, e.Got)
+}
+
+func (e *WrongLengthError) Is(target error) bool {
+	return target == ErrWrongLength
+}
+
+// An InvalidHintCharError is the error for a hint containing an unexpected character.
+type InvalidHintCharError struct {
+	// Position is the index of Char in the hint.
+	Position int
+	Char     byte
+}
+
+func (e *InvalidHintCharError) Error() string {
+	return fmt.Sprintf("unexpected hint %v, use absent = b (black), present = y (yellow), correct = g (green), unknown = ?", string(e.Char))
+}
+
+func (e *InvalidHintCharError) Is(target error) bool {
+	return target == ErrInvalidHintChar
+}
+
+// An InvalidWordCharError is the error for a word containing something other than a lower case letter.
+type InvalidWordCharError struct {
+	Word string
+
+	// Position is the index of Char in the word.
+	Position int
+	Char     byte
+}
+
+func (e *InvalidWordCharError) Error() string {
+	return fmt.Sprintf("unexpected character %q in %q, words must be made of lower case letters", e.Char, e.Word)
+}
+
+func (e *InvalidWordCharError) Is(target error) bool {
+	return target == ErrInvalidWordChar
+}
+
+// An UnknownAnswerError is the error for an answer which isn't in the dictionary, so it can't be found.
+type UnknownAnswerError struct {
+	Answer string
+
+	// GuessOnly is whether Answer is one of the words which may only be guessed, see GameOptions.Guesses.
+	GuessOnly bool
+}
+
+func (e *UnknownAnswerError) Error() string {
+	if e.GuessOnly {
+		return fmt.Sprintf("answer %v is only in the guesses, not the dictionary of possible answers", e.Answer)
+	}
+
+	return fmt.Sprintf("answer %v isn't in the dictionary or guesses", e.Answer)
+}
+
+func (e *UnknownAnswerError) Is(target error) bool {
+	return target == ErrUnknownAnswer
+}
+
+// A PanicError is the error for a panic while playing a game, returned instead of panicking by e.g. Game.PlaySafe.
+type PanicError struct {
+	// Value is the value the game panicked with.
+	Value interface{}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panicked: %v", e.Value)
+}
+
+func (e *PanicError) Is(target error) bool {
+	return target == ErrPanicked
+}
+
+// Unwrap returns Value if it's an error, so that errors.Is and errors.As can match it.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// recoverPanic recovers from a panic, if there is one, and sets err to a *PanicError for it. It must be deferred.
+func recoverPanic(err *error) {
+	if value := recover(); value != nil {
+		*err = &PanicError{Value: value}
+	}
+}
+
+// checkWord returns an error if word can't be a guess or answer: if it's the wrong size or has characters other than
+// lower case letters.
+func checkWord(word string) error {
+	if len(word) != wordSize {
+		return &WrongLengthError{Expected: wordSize, Got: len(word)}
+	}
+
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return &InvalidWordCharError{Word: word, Position: i, Char: word[i]}
+		}
+	}
+
+	return nil
+}
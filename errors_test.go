@@ -0,0 +1,142 @@
+package wordle
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestInvalidHintCharError(t *testing.T) {
+	_, err := NewConstraint("tares", "bygxb")
+
+	var hintErr *InvalidHintCharError
+	if !errors.As(err, &hintErr) {
+		t.Fatalf("error %v isn't an *InvalidHintCharError", err)
+	}
+
+	if hintErr.Position != 3 || hintErr.Char != 'x' {
+		t.Errorf("invalid hint char at %v is %q, want x at 3", hintErr.Position, hintErr.Char)
+	}
+
+	if !errors.Is(err, ErrInvalidHintChar) {
+		t.Errorf("error %v doesn't match ErrInvalidHintChar", err)
+	}
+}
+
+func TestWrongLengthError(t *testing.T) {
+	_, err := NewConstraint("tares", "byg")
+
+	var lengthErr *WrongLengthError
+	if !errors.As(err, &lengthErr) {
+		t.Fatalf("error %v isn't a *WrongLengthError", err)
+	}
+
+	if lengthErr.Expected != wordSize || lengthErr.Got != 3 {
+		t.Errorf("wrong length error expected %v and got %v, want %v and 3", lengthErr.Expected, lengthErr.Got, wordSize)
+	}
+
+	if !errors.Is(err, ErrWrongLength) || errors.Is(err, ErrInvalidHintChar) {
+		t.Errorf("error %v matches the wrong sentinel errors", err)
+	}
+}
+
+func TestInvalidWordCharError(t *testing.T) {
+	_, err := NewConstraint("ta3es", "bygbb")
+
+	var wordErr *InvalidWordCharError
+	if !errors.As(err, &wordErr) {
+		t.Fatalf("error %v isn't an *InvalidWordCharError", err)
+	}
+
+	if wordErr.Position != 2 || wordErr.Char != '3' {
+		t.Errorf("invalid word char at %v is %q, want 3 at 2", wordErr.Position, wordErr.Char)
+	}
+
+	if !errors.Is(err, ErrInvalidWordChar) {
+		t.Errorf("error %v doesn't match ErrInvalidWordChar", err)
+	}
+}
+
+func TestPlaySafeEmptyDictionary(t *testing.T) {
+	defer quiet()()
+
+	g, err := NewGame(GameOptions{Dictionary: []string{"bills", "fills", "hills"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	previous := stdin
+	stdin = bufio.NewReader(strings.NewReader("bills\nbbbbb\n"))
+	defer func() {
+		stdin = previous
+	}()
+
+	// The hint rules out every possible answer.
+	_, err = g.PlaySafe()
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || !errors.Is(err, ErrPanicked) {
+		t.Errorf("PlaySafe() with no possible answers left = %v, want a *PanicError", err)
+	}
+}
+
+func TestUnknownAnswerError(t *testing.T) {
+	dictionary := []string{"bills", "fills", "hills"}
+
+	tests := []struct {
+		answer    string
+		guessOnly bool
+	}{
+		{"moist", false},
+		{"crane", true},
+	}
+
+	for _, test := range tests {
+		_, err := NewGame(GameOptions{Dictionary: dictionary, Guesses: []string{"crane"}, Answer: test.answer})
+
+		var answerErr *UnknownAnswerError
+		if !errors.As(err, &answerErr) || !errors.Is(err, ErrUnknownAnswer) {
+			t.Fatalf("error %v for answer %v isn't an *UnknownAnswerError", err, test.answer)
+		}
+
+		if answerErr.Answer != test.answer || answerErr.GuessOnly != test.guessOnly {
+			t.Errorf("unknown answer error is for %v (guess only: %v), want %v (guess only: %v)", answerErr.Answer,
+				answerErr.GuessOnly, test.answer, test.guessOnly)
+		}
+	}
+}
+
+func TestSolveBadAnswer(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   error
+	}{
+		{"zzzzz", ErrUnknownAnswer},
+		{"bill", ErrWrongLength},
+		{"billss", ErrWrongLength},
+		{"Bills", ErrInvalidWordChar},
+	}
+
+	for _, test := range tests {
+		_, err := Solve(GameOptions{Dictionary: Answers[:50], Answer: test.answer})
+		if !errors.Is(err, test.want) || errors.Is(err, ErrPanicked) {
+			t.Errorf("Solve() for answer %q = %v, want %v", test.answer, err, test.want)
+		}
+	}
+
+	if _, err := Solve(GameOptions{Dictionary: Answers[:50]}); err == nil || errors.Is(err, ErrPanicked) {
+		t.Errorf("Solve() without an answer = %v, want an error which isn't a panic", err)
+	}
+}
+
+func TestSolveBatchBadAnswer(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("SolveBatch() with an answer that isn't in the dictionary didn't panic")
+		}
+	}()
+
+	// The panic would crash the test binary if it happened while solving.
+	SolveBatch(GameOptions{Dictionary: Answers[:50]}, []string{Answers[0], "zzzzz"}, 2)
+}
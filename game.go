@@ -1,14 +1,99 @@
 package wordle
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"math/rand"
 	"runtime"
+	"sort"
+	"strings"
+	"time"
 )
 
+// A Game is a game of Wordle being solved. A Game can't be used by more than one goroutine at once, but different games
+// can be played concurrently.
 type Game struct {
 	dictionary []string
+	version    uint64
 	p          player
+	options    GameOptions
+
+	// allowed holds every word that may be guessed, including ones which have been ruled out as the answer.
+	allowed []string
+
+	// guessOnly holds the words which may be guessed but can't be the answer, and which are consistent with the hints
+	// revealed so far.
+	guessOnly []string
+
+	// scores holds the score of every word in dictionary, if it has been calculated yet.
+	scores []ScoredGuess
+
+	// turns holds the guesses made so far.
+	turns []Turn
+
+	// knowledge holds what the hints revealed so far say about the answer.
+	knowledge knowledge
+
+	// filters holds everything which narrowed down the possible answers so far, in order, including hints ignored
+	// because they must have been wrong. startDictionary and startGuessOnly hold the words from before any of them. Used
+	// to undo a wrong hint - see GameOptions.Recover.
+	filters                         []wordFilter
+	startDictionary, startGuessOnly []string
+
+	// startVersion is the version of startDictionary.
+	startVersion uint64
+
+	// pool calculates the entropy of potential guesses. It's workerPool unless the game is part of a batch - see
+	// SolveBatch.
+	pool entropyWorkerPool
+
+	// progress, if set, is called like GameOptions.OnProgress. Used to show progress to a person playing the game.
+	progress func(done float64)
+}
+
+// An EntropyReference is a dictionary the entropy of guesses can be calculated against. See
+// GameOptions.EntropyReference.
+type EntropyReference int
+
+const (
+	// CurrentRemaining calculates entropy against the possible answers left, which is how much a guess is expected to
+	// narrow them down.
+	CurrentRemaining EntropyReference = iota
+
+	// OriginalFull calculates entropy against the dictionary the game started with, throughout the game. This scores
+	// guesses on a stable basis, e.g. to compare guesses made at different stages of games. It isn't a good way to
+	// choose guesses, since it ignores what the hints revealed.
+	OriginalFull
+)
+
+// A TiePolicy is a way of choosing between guesses which are tied for the best. See GameOptions.TiePolicy.
+type TiePolicy int
+
+const (
+	// TieFirst chooses the first of the tied guesses, unless GameOptions.Rand is set, in which case it chooses randomly
+	// like TieRandom.
+	TieFirst TiePolicy = iota
+
+	// TieRandom chooses randomly using GameOptions.Rand, which must be set.
+	TieRandom
+
+	// TieWeight chooses the tied guess with the highest weight in GameOptions.Weights, i.e. the most likely answer. Of
+	// guesses with the same weight, the first is chosen.
+	TieWeight
+
+	// TieAlphabetical chooses the tied guess which comes first alphabetically.
+	TieAlphabetical
+
+	// TieScorer chooses the tied guess with the highest score according to GameOptions.Scorer, which must be set. Of
+	// guesses with the same score, the first is chosen.
+	TieScorer
+)
+
+// A ScoredGuess is a potential guess along with its score. See the method returning it for what the score means.
+type ScoredGuess struct {
+	Word  string
+	Score float64
 }
 
 type player interface {
@@ -25,23 +110,478 @@ type GameOptions struct {
 	// Otherwise:
 	//  - Hints are self-calculated because the answer is known. Useful for seeing how the solver reacts to certain answers.
 	//  - In this mode, the solver always chooses the best guess.
+	//  - The answer must be in the dictionary, otherwise NewGame returns an error.
 	Answer string
+
+	// Dictionary is the list of words the answer can be. If nil, DictionarySource is used.
+	Dictionary []string
+
+	// DictionarySource provides the words the answer can be, if Dictionary isn't set. If nil, ValidWords is used. Only
+	// one of Dictionary and DictionarySource can be set.
+	DictionarySource DictionarySource
+
+	// Guesses is a list of extra words which may be guessed, but which can't be the answer. Like the words in the
+	// dictionary, they're only used as the best guess if they're consistent with the hints revealed so far.
+	Guesses []string
+
+	// AnswersOnly only ever uses words which could be the answer as the best guess, never words from Guesses, even
+	// when one would reveal more. This has no effect if Guesses is empty, as every candidate is then in the dictionary.
+	// FirstGuess must be in the dictionary too. The only exception is when every remaining answer is blocked or has
+	// already been guessed, in which case any allowed word may be used as the best guess.
+	AnswersOnly bool
+
+	// Strategy is how guesses are scored to choose the best guess: by entropy, the default, or e.g. by the information
+	// they're guaranteed to reveal with MinimaxStrategy. Other than EntropyStrategy, the cached first guess and
+	// DecisionTree aren't used. It can be changed part way through a game with Game.SetStrategy.
+	Strategy Strategy
+
+	// SkipInvalidWords leaves words in the dictionary or Guesses which can't be guessed (e.g. because they have
+	// characters other than lower case letters) out of the game, printing a warning for each. Otherwise, NewGame
+	// returns an error for them.
+	SkipInvalidWords bool
+
+	// BlockedGuesses holds words which are never chosen as the best guess, e.g. offensive words. They're still possible
+	// answers. If every word which can be the best guess is blocked, the best unblocked word is chosen from all the
+	// allowed words. Each blocked word must be in the dictionary or Guesses.
+	BlockedGuesses map[string]bool
+
+	// Weights, if set, holds how likely each word in the dictionary is to be the answer, relative to the others, e.g.
+	// how common the word is. Words without a weight can't be the answer. Weights are used wherever how likely a word
+	// is to be the answer matters: breaking ties with TieWeight, and the probabilities of Game.CandidateProbabilities
+	// and everything built on them, like GameResult.Confidence, Game.GuessOutcomeOdds and Game.PositionEntropy. They
+	// don't change the entropy of guesses, which counts every possible answer the same. If none of the remaining words
+	// have a weight, each is equally likely. NewGameFromWordList sets them from a word list. Weights can't be negative.
+	Weights map[string]float64
+
+	// FirstGuess, if set, is used as the best guess for the first turn instead of calculating it, even if the answer is
+	// known, e.g. by Game.BestGuess, Solve and Benchmark too. Useful for seeing how the solver copes with a different
+	// start. It must be in the dictionary or Guesses.
+	FirstGuess string
+
+	// Rand, if set, is used to choose randomly between guesses which are tied for the best. This adds variety when
+	// solving many games, while staying reproducible for a given seed. Otherwise, the first of the tied guesses is chosen.
+	// The cached first guess is always used as is. See TiePolicy for other ways of choosing.
+	Rand *rand.Rand
+
+	// TiePolicy is how to choose between guesses which are tied for the best, e.g. between possible answers in the
+	// endgame. By default, ties are broken by Rand if set, and otherwise the first guess is chosen. The cached first
+	// guess is always used as is.
+	TiePolicy TiePolicy
+
+	// Scorer scores guesses for breaking ties with TieScorer, where the guess with the highest score is chosen. E.g.
+	// ErgonomicScore prefers guesses which are easy to type. Scorers can be combined by adding up their scores, weighted
+	// by how much each should matter.
+	Scorer func(word string) float64
+
+	// TieTolerance is how much less a guess can be worth than the best guess to still be tied with it, e.g. so that
+	// TieScorer can choose between guesses which are almost as good. By default, only guesses worth the same are tied.
+	// It's unused with TieFirst.
+	TieTolerance float64
+
+	// ConfirmFinal keeps playing once one possible answer is left, until guessing it is confirmed by an all correct
+	// hint. If the hint isn't all correct, an earlier hint must have been wrong (e.g. mistyped), and Play panics.
+	// Otherwise, the game ends as soon as one possible answer is left.
+	ConfirmFinal bool
+
+	// Recover, if set, recovers from a hint leaving no possible answers (e.g. the only possible answer not being
+	// confirmed, see ConfirmFinal) instead of panicking. An earlier hint must have been wrong (e.g. misread), so the most
+	// suspicious hint is ignored and the game continues. The most suspicious hint is the one which, when ignored, leaves
+	// the fewest possible answers, since that assumes the least about what went wrong. Only useful if the answer is
+	// unknown.
+	Recover bool
+
+	// PuzzleNumber is the number of the puzzle being played, included when sharing the result. See GameResult.ShareText.
+	PuzzleNumber int
+
+	// MaxGuesses is the number of guesses allowed before the game is lost. If zero, the standard 6 guesses are allowed.
+	// The solver keeps playing past it, but the result is shared as a loss.
+	MaxGuesses int
+
+	// EntropyReference is the dictionary the entropy of guesses is calculated against. By default, it's the possible
+	// answers left.
+	EntropyReference EntropyReference
+
+	// Lambda, if set, shifts the choice of guess from gaining information towards winning outright, based on the number
+	// of possible answers left. Guesses are chosen by:
+	//
+	//  entropy + Lambda(remaining) * probability the guess is the answer
+	//
+	// For example, a Lambda which is small while many answers remain but large near the end of the game explores early
+	// and exploits late. Otherwise, guesses are chosen purely by entropy. The cached first guess is always used as is.
+	Lambda func(remaining int) float64
+
+	// GuessBudget, if set, is the number of guesses the game should be won within. The closer the game gets to running
+	// out of guesses, the more guesses are chosen to guarantee narrowing down the answer (minimizing the number of
+	// possible answers left in the worst case) rather than to narrow it down the most on average. The last two guesses
+	// of the budget are chosen to be the most likely to win within it. This sacrifices the average number of guesses for
+	// a better chance of winning within the budget. The cached first guess is always used as is.
+	GuessBudget int
+
+	// CandidateTopK, if set, limits the guesses Lambda and GuessBudget choose between to the CandidateTopK with the
+	// highest entropy. Working out how guaranteed a guess is to narrow down the answer is much slower than its
+	// entropy, so this speeds up large games at the risk of missing a guess with low entropy which would have been
+	// chosen. It has no effect if neither Lambda nor GuessBudget is set, or if it's at least the number of candidates.
+	CandidateTopK int
+
+	// DecisionTree, if set, is followed to choose best guesses instantly instead of calculating them: the guess of the
+	// node reached by following the hints revealed so far from the root is used. If the guesses made so far aren't the
+	// tree's, or the tree doesn't go that deep, the best guess is calculated as usual. The tree should be built by
+	// BuildDecisionTree with the same options (aside from the answer), otherwise its guesses may not be the ones the
+	// options would choose.
+	DecisionTree *DecisionNode
+
+	// Trace records runtime/trace regions while calculating the first guess, the most expensive one: a
+	// "wordle.firstGuess" region around the whole calculation, and a "wordle.entropy" region around handing each
+	// candidate to the entropy workers and collecting their results. They show up in go tool trace when a trace is
+	// started with trace.Start, and cost nothing otherwise. It doesn't change which guesses are chosen.
+	Trace bool
+
+	// ShowEliminated, if set, is the number of example words to print each turn which were possible answers until the
+	// latest hint ruled them out. Only used if Verbose is set.
+	ShowEliminated int
+
+	// ShowConfidence includes the probability of the answer being right when printing it at the end of the game. See
+	// GameResult.Confidence.
+	ShowConfidence bool
+
+	// EntropyAsWords also prints the entropy of each candidate as the number of words it's expected to eliminate, which
+	// is easier to make sense of than bits. See wordsEliminated. Only used if Verbose is set.
+	EntropyAsWords bool
+
+	// OnBestUpdate, if set, is called with the best guess so far while the best guess is being calculated, along with
+	// the fraction of candidates scored so far. The best guess so far is by entropy alone. Once the best guess is
+	// chosen, it's called with it and a fraction of 1.
+	OnBestUpdate func(best ScoredGuess, done float64)
+
+	// OnTurn, if set, is called with each turn played by Game.Play once its hint has been applied, e.g. to save the game
+	// so far. Turns applied with Game.Apply or Game.Replay aren't included.
+	OnTurn func(turn Turn)
+
+	// Metrics, if set, records observations about the game, like the number of guesses it took and how long
+	// calculating each best guess took. See the Metric constants for what's observed.
+	Metrics Metrics
+
+	// OnAnswer, if set, is called with the answer at the end of a game played by Game.Play or solved by e.g. Solve or
+	// Benchmark, e.g. to look up and print its definition.
+	OnAnswer func(word string)
+
+	// OnProgress, if set, is called with the fraction of candidates scored so far while the best guess is being
+	// calculated, ending with 1. It isn't called when the best guess doesn't need calculating, e.g. for the cached
+	// first guess.
+	OnProgress func(done float64)
+
+	// Messages overrides the prompts and labels shown while playing, e.g. to show them in another language.
+	Messages Messages
+
+	// ShowClusters prints the largest groups of possible answers left each turn which only differ by one letter, e.g.
+	// "_ills". Such groups make the game hard, since a guess can only rule out a few of their words. Only used if Verbose
+	// is set.
+	ShowClusters bool
+
+	// RefuseUselessGuesses makes typed guesses which can't rule out any possible answer be entered again, instead of
+	// only warning about them. Such guesses give the same hint whichever possible answer is the answer, so they waste
+	// a turn.
+	RefuseUselessGuesses bool
+
+	// ShowGap includes the gap between the entropy of the best guess and the runner-up when printing the best guess
+	// (see Turn.Gap). Only used if Verbose is set.
+	ShowGap bool
+
+	// DumpDir, if set, is a directory the possible answers left are written to after each turn, one file per turn (e.g.
+	// turn_1.txt) holding one word per line. Useful for debugging tricky games. If the answer is known, the files are in
+	// a subdirectory named after it (e.g. crane/turn_1.txt), so games solved by e.g. SolveBatch or Benchmark each get
+	// their own, and each board of a MultiGame has its own subdirectory too. The directory is created if needed, and
+	// failing to write a file only prints a warning.
+	DumpDir string
+
+	// Tutorial explains each guess in plain sentences, e.g. how it splits up the remaining words and how many words it's
+	// expected to eliminate. Only used if Verbose is set.
+	Tutorial bool
 }
 
 // NewGame creates a new game of Wordle. See GameOptions for game configuration. The solver solves using hard-mode rules.
-func NewGame(options GameOptions) *Game {
-	var p player = &humanPlayer{}
+// It returns an error if the options can't be used to play a game.
+func NewGame(options GameOptions) (*Game, error) {
+	if options.Dictionary != nil && options.DictionarySource != nil {
+		return nil, errors.New("only one of Dictionary and DictionarySource can be set")
+	}
+
+	dictionary, err := checkWords(options.dictionarySource().Words(), options.SkipInvalidWords)
+	if err != nil {
+		return nil, fmt.Errorf("bad dictionary: %w", err)
+	}
+
+	guesses, err := checkWords(options.Guesses, options.SkipInvalidWords)
+	if err != nil {
+		return nil, fmt.Errorf("bad guesses: %w", err)
+	}
+
+	if len(dictionary) == 0 {
+		return nil, errors.New("empty dictionary: there must be at least one possible answer")
+	}
+
+	g := &Game{
+		dictionary: dictionary,
+		version:    newDictionaryVersion(),
+		options:    options,
+		allowed:    dictionary,
+		knowledge:  newKnowledge(),
+		pool:       workerPool,
+	}
+
+	if len(guesses) != 0 {
+		inDictionary := make(map[string]bool, len(dictionary))
+		for _, word := range dictionary {
+			inDictionary[word] = true
+		}
+
+		for _, word := range guesses {
+			if !inDictionary[word] {
+				g.guessOnly = append(g.guessOnly, word)
+			}
+		}
+
+		g.allowed = append(append([]string(nil), dictionary...), g.guessOnly...)
+	}
+
+	if len(options.BlockedGuesses) != 0 {
+		isAllowed := make(map[string]bool, len(g.allowed))
+		for _, word := range g.allowed {
+			isAllowed[word] = true
+		}
+
+		for word, blocked := range options.BlockedGuesses {
+			if blocked && !isAllowed[word] {
+				return nil, fmt.Errorf("blocked guess %v isn't in the dictionary or guesses", word)
+			}
+		}
+	}
+
+	for word, weight := range options.Weights {
+		if weight < 0 {
+			return nil, fmt.Errorf("weight of %v is negative: %v", word, weight)
+		}
+	}
+
+	if options.TiePolicy == TieRandom && options.Rand == nil {
+		return nil, errors.New("breaking ties randomly needs Rand to be set")
+	}
+
+	if options.Strategy != EntropyStrategy && options.Strategy != MinimaxStrategy {
+		return nil, fmt.Errorf("unknown strategy %v", options.Strategy)
+	}
+
+	if options.TiePolicy == TieScorer && options.Scorer == nil {
+		return nil, errors.New("breaking ties by score needs Scorer to be set")
+	}
+
+	if options.TieTolerance < 0 {
+		return nil, fmt.Errorf("tie tolerance is negative: %v", options.TieTolerance)
+	}
+
+	if options.CandidateTopK < 0 {
+		return nil, fmt.Errorf("candidate top k is negative: %v", options.CandidateTopK)
+	}
+
+	if options.FirstGuess != "" {
+		found := false
+		for _, word := range g.allowed {
+			found = found || word == options.FirstGuess
+		}
+
+		if !found {
+			return nil, fmt.Errorf("first guess %v isn't in the dictionary or guesses", options.FirstGuess)
+		}
+
+		if options.AnswersOnly && !g.isPossible(options.FirstGuess) {
+			return nil, fmt.Errorf("first guess %v isn't in the dictionary, but only answers may be guessed", options.FirstGuess)
+		}
+	}
+
+	if options.Answer != "" {
+		if err := checkAnswer(options.Answer, g.dictionary, g.guessOnly); err != nil {
+			return nil, fmt.Errorf("bad answer: %w", err)
+		}
+	}
+
+	g.startDictionary, g.startGuessOnly, g.startVersion = g.dictionary, g.guessOnly, g.version
+
 	if options.Answer != "" {
-		p = computerPlayer{answer: options.Answer}
+		g.p = computerPlayer{answer: options.Answer}
+	} else {
+		g.p = &humanPlayer{game: g}
+	}
+
+	return g, nil
+}
+
+// NewGameFromWordList creates a new game of Wordle for when the official list of answers isn't available, only a
+// general word list, e.g. one loaded by LoadDictionary. Words which can't be a guess (e.g. because they're the wrong
+// length) are left out silently, and upper case letters are lowered, so lists of any words can be used.
+//
+// Every word in the list can be guessed. If weights is nil, every word can also be the answer. Otherwise, weights
+// holds how likely each word is to be the answer (e.g. its frequency in English), like GameOptions.Weights: only words
+// with a positive weight can be the answer, and ties between guesses are broken in favor of likelier answers. The
+// answer is unknown, so hints are entered by the player, like NewGame with default options.
+//
+// It returns an error if no word in the list can be the answer.
+func NewGameFromWordList(words []string, weights map[string]float64) (*Game, error) {
+	var options GameOptions
+
+	seen := map[string]bool{}
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if seen[word] || checkWord(word) != nil {
+			continue
+		}
+		seen[word] = true
+
+		options.Guesses = append(options.Guesses, word)
+		if weights == nil || weights[word] > 0 {
+			options.Dictionary = append(options.Dictionary, word)
+		}
+	}
+
+	if len(options.Dictionary) == 0 {
+		return nil, errors.New("no word in the word list can be the answer")
+	}
+
+	if weights != nil {
+		options.Weights = make(map[string]float64, len(options.Dictionary))
+		for _, word := range options.Dictionary {
+			options.Weights[word] = weights[word]
+		}
+		options.TiePolicy = TieWeight
+	}
+
+	return NewGame(options)
+}
+
+// checkAnswer returns an error if answer can't be found by a game with the given dictionary and guess only words: if
+// it can't be a guess at all (see checkWord), or if it isn't in dictionary. The error is then an *UnknownAnswerError.
+func checkAnswer(answer string, dictionary, guessOnly []string) error {
+	if err := checkWord(answer); err != nil {
+		return err
+	}
+
+	for _, word := range dictionary {
+		if word == answer {
+			return nil
+		}
+	}
+
+	for _, word := range guessOnly {
+		if word == answer {
+			return &UnknownAnswerError{Answer: answer, GuessOnly: true}
+		}
+	}
+
+	return &UnknownAnswerError{Answer: answer}
+}
+
+// checkWords returns an error if any of words can't be a guess or answer - see checkWord. If skip is set, such words
+// are left out of the result with a warning instead.
+func checkWords(words []string, skip bool) ([]string, error) {
+	var result []string
+
+	for i, word := range words {
+		err := checkWord(word)
+		if err == nil {
+			if result != nil {
+				result = append(result, word)
+			}
+			continue
+		}
+
+		if !skip {
+			return nil, err
+		}
+
+		fmt.Printf("Skipping invalid word %q: %v\n", word, err)
+		if result == nil {
+			result = append(make([]string, 0, len(words)), words[:i]...)
+		}
+	}
+
+	if result == nil {
+		return words, nil
+	}
+
+	return result, nil
+}
+
+// ExcludedAt returns the letters which the hints revealed so far rule out at position pos of the answer (counting
+// from 0), in alphabetical order: letters which were present elsewhere or absent when guessed there, and letters which
+// aren't in the answer at all. Once the letter at pos is known, every other letter is ruled out. It panics if pos isn't
+// a position in a word.
+func (g *Game) ExcludedAt(pos int) []byte {
+	if pos < 0 || pos >= wordSize {
+		panic(fmt.Sprintf("position %v isn't between 0 and %v", pos, wordSize-1))
+	}
+
+	var result []byte
+	for letter := byte('a'); letter <= 'z'; letter++ {
+		if !g.knowledge.allowed[pos].contains(letter) || g.knowledge.maxCount[letter-'a'] == 0 {
+			result = append(result, letter)
+		}
+	}
+
+	return result
+}
+
+// Answer returns the answer of g and true if it's known, i.e. if the computer is playing against GameOptions.Answer.
+// When a person is playing, the answer is unknown and it returns false.
+func (g *Game) Answer() (string, bool) {
+	if c, ok := g.p.(computerPlayer); ok {
+		return c.answer, true
+	}
+
+	return "", false
+}
+
+// Clone returns a copy of g which can be played independently of it, e.g. to explore what would happen after a guess.
+func (g *Game) Clone() *Game {
+	clone := *g
+
+	clone.dictionary = append([]string(nil), g.dictionary...)
+	clone.guessOnly = append([]string(nil), g.guessOnly...)
+	clone.turns = append([]Turn(nil), g.turns...)
+	clone.filters = append([]wordFilter(nil), g.filters...)
+
+	if _, ok := g.p.(*humanPlayer); ok {
+		clone.p = &humanPlayer{game: &clone}
 	}
 
-	return &Game{
-		dictionary: ValidWords,
-		p:          p,
+	return &clone
+}
+
+// SetStrategy changes how the best guess is chosen from now on, as if GameOptions.Strategy was strategy. It panics if
+// strategy is unknown.
+func (g *Game) SetStrategy(strategy Strategy) {
+	if strategy != EntropyStrategy && strategy != MinimaxStrategy {
+		panic(fmt.Sprintf("unknown strategy %v", strategy))
 	}
+
+	g.options.Strategy = strategy
+}
+
+// SetAnswersOnly changes whether only possible answers are used as the best guess from now on, as if
+// GameOptions.AnswersOnly was answersOnly.
+func (g *Game) SetAnswersOnly(answersOnly bool) {
+	g.options.AnswersOnly = answersOnly
+}
+
+// PlaySafe plays the game like Game.Play, but returns an error instead of panicking, e.g. if a hint rules out every
+// possible answer or reading a typed guess fails. The error is a *PanicError. Useful when a game is embedded in
+// something which has to keep running, like a server.
+func (g *Game) PlaySafe() (result GameResult, err error) {
+	defer recoverPanic(&err)
+
+	return g.Play(), nil
 }
 
-// Play plays a game of Wordle. It returns the answer and the number of guesses needed to arrive at it.
+// Play plays a game of Wordle. It returns the result of the game, including the answer and the number of guesses needed
+// to arrive at it.
 //
 // A game is played by repeatedly guessing. Each guess yields a hint, which narrows down the solution to a smaller set of potential words.
 //
@@ -49,19 +589,44 @@ func NewGame(options GameOptions) *Game {
 //
 // This process repeats until there is one word left - it is the answer.
 //
+// The number of guesses includes guessing the answer itself, even if the game ends before it's guessed because it's
+// the only word left. So a game with a dictionary of one word takes one guess, and a game where the first guess is
+// the answer also takes one guess.
+//
 // At each step, the best guess is chosen given the information revealed so far. See Game.getBestGuess for details.
-func (g *Game) Play() (string, int) {
-	guessCount := 1
+func (g *Game) Play() GameResult {
+	start := time.Now()
+	guessCount := len(g.turns) + 1
+	solved := false
+	messages := g.options.Messages.withDefaults()
+	startHits, startLookups := g.pool.cacheStats()
 
-	for len(g.dictionary) != 1 {
+	if Verbose && len(g.turns) == 0 {
+		fmt.Printf("Theoretical minimum: %v guesses to narrow down the answer\n", InfoLowerBound(len(g.dictionary)))
+		fmt.Println()
+	}
 
-		if Verbose {
+	for len(g.dictionary) != 1 || g.options.ConfirmFinal {
+		if len(g.dictionary) == 1 {
+			fmt.Println(messages.ConfirmFinal+":", g.dictionary[0])
+		}
+
+		_, lookup := g.lookupBestGuess(len(g.turns) == 0)
+		if Verbose && !lookup {
 			fmt.Printf("(Guess #%v) Calculating best guess...\n", guessCount)
 		}
-		bestGuess, bestEntropy := g.getBestGuess(guessCount == 1)
+
+		if _, interactive := g.p.(*humanPlayer); interactive && !Verbose && !lookup {
+			g.progress = newProgressPrinter(messages.Calculating)
+		}
+		bestGuess, bestEntropy := g.getBestGuess(len(g.turns) == 0)
+		g.progress = nil
 
 		if Verbose {
-			fmt.Printf("(Guess #%v) Best guess: %v (expected entropy: %v)\n", guessCount, bestGuess, bestEntropy)
+			fmt.Printf("(Guess #%v) Best guess: %v (expected entropy: %v, expected remaining: %.1f)\n", guessCount, bestGuess, bestEntropy, g.ExpectedRemaining(bestGuess))
+			if gap, ok := g.gap(); ok && g.options.ShowGap {
+				fmt.Printf("(Guess #%v) Gap:        %v ahead of the runner-up\n", guessCount, gap)
+			}
 		}
 
 		guess := g.p.getGuess(bestGuess)
@@ -72,64 +637,1229 @@ func (g *Game) Play() (string, int) {
 			fmt.Printf("(Guess #%v) Hint:       %v\n", guessCount, hint)
 		}
 
+		if Verbose && g.options.Tutorial {
+			g.explainGuess(guess, bestGuess)
+		}
+
 		previousSize := len(g.dictionary)
 
-		c := constraint{
-			hint: hint,
-			word: guess,
+		var eliminated []string
+		if Verbose && g.options.ShowEliminated > 0 {
+			eliminated = Constraint{hint: hint, word: guess}.eliminated(g.dictionary, g.options.ShowEliminated)
 		}
-		g.dictionary = c.filter(g.dictionary)
+
+		g.apply(guess, hint)
 
 		if Verbose {
-			fmt.Printf("(Guess #%v) Dict size:  %v -> %v (actual entropy: %v)\n", guessCount, previousSize, len(g.dictionary), math.Log2(float64(previousSize)/float64(len(g.dictionary))))
+			fmt.Printf("(Guess #%v) Dict size:  %v -> %v (actual entropy: %v)\n", guessCount, previousSize, len(g.dictionary), InfoGained(previousSize, len(g.dictionary)))
+			if len(g.dictionary) > 1 {
+				fmt.Printf("(Guess #%v) Mean entropy: %v (guessing a possible answer)\n", guessCount, g.MeanCandidateEntropy())
+				fmt.Printf("(Guess #%v) Positions:  %v\n", guessCount, positionBar(g.PositionEntropy()))
+			}
+			if len(eliminated) != 0 {
+				fmt.Printf("(Guess #%v) Eliminated: %v\n", guessCount, strings.Join(eliminated, ", "))
+			}
+			if g.options.ShowClusters {
+				if summary := summarizeClusters(g.dictionary); summary != "" {
+					fmt.Printf("(Guess #%v) Clusters:   %v\n", guessCount, summary)
+				}
+			}
+			if g.options.Tutorial {
+				fmt.Printf("The hint %v left %v of the %v words.\n", hint, len(g.dictionary), previousSize)
+			}
 			fmt.Println()
 		}
 
+		if len(g.dictionary) == 0 && g.options.Recover {
+			if turn, ok := g.recover(); ok {
+				fmt.Printf(messages.IgnoredHint+"\n", hint, turn.Hint, turn.Guess)
+				fmt.Println()
+				guessCount++
+				continue
+			}
+		}
+
+		if len(g.dictionary) == 0 && previousSize == 1 {
+			panic(fmt.Sprintf("The hint %v didn't confirm the only possible answer. "+
+				"An earlier hint must have been wrong - make sure the guesses/hints were typed correctly.", hint))
+		}
+
 		if len(g.dictionary) == 0 {
 			panic("That guess resulted in the dictionary being empty - no answer could be found. " +
 				"If the answer is unknown, make sure the guess/hint were typed correctly. " +
 				"If they were, or the answer is known, there's a bug somewhere.")
 		}
 
+		if g.options.OnTurn != nil {
+			g.options.OnTurn(g.turns[len(g.turns)-1])
+		}
+
+		if hint.solved() {
+			solved = true
+			break
+		}
+
+		if _, interactive := g.p.(*humanPlayer); (Verbose || interactive) && g.needsLuck() {
+			fmt.Println(messages.NeedsLuck)
+		}
+
 		guessCount++
 	}
 
-	fmt.Println("Answer: ", g.dictionary[0])
-	fmt.Println("Guesses:", guessCount)
+	if Verbose {
+		hits, lookups := g.pool.cacheStats()
+		hits, lookups = hits-startHits, lookups-startLookups
+		if lookups != 0 {
+			fmt.Printf("Entropy cache hit rate: %.1f%% (%v/%v)\n", 100*float64(hits)/float64(lookups), hits, lookups)
+		}
+	}
+
+	if _, ok := g.p.(*humanPlayer); ok && !solved {
+		fmt.Println()
+		fmt.Printf(messages.GuessToWin+"\n", g.dictionary[0])
+		fmt.Println(messages.OnlyWordLeft)
+		fmt.Println()
+	}
+
+	result := g.result()
+	g.observeGame(result, start)
+
+	if g.options.ShowConfidence {
+		fmt.Printf("%v:  %v (confidence: %.1f%%)\n", messages.Answer, result.Answer, 100*result.Confidence)
+	} else {
+		fmt.Println(messages.Answer+": ", result.Answer)
+	}
+	fmt.Println(messages.Guesses+":", guessCount)
 
-	return g.dictionary[0], guessCount
+	if g.options.OnAnswer != nil {
+		g.options.OnAnswer(result.Answer)
+	}
+
+	return result
 }
 
-// The worker pool used to calculate the entropy of potential guesses.
-var workerPool = newEntropyWorkerPool(runtime.NumCPU())
+// The number of best guesses recorded in Turn.TopGuesses.
+const turnTopGuesses = 5
 
-// getBestGuess returns the best guess to make at this stage of the game.
-//
-// It does so by choosing the word which will narrow down the number of potential answers the most. In other words, the
-// words which provides the most information. In other words: the words with the highest entropy.
-//
-// See entropyWorker.calculateEntropy for details on the entropy calculation.
-//
-// The first guess has no prior information, and thus is solely based on the dictionary of words.
-// It also takes the longest to compute. So, it's calculated once and cached.
-func (g *Game) getBestGuess(firstGuess bool) (string, float64) {
-	if firstGuess {
-		return "tares", 6.194052544375467
+// apply narrows down the possible answers using the hint guess resulted in, and records the turn.
+func (g *Game) apply(guess string, hint wordHint) {
+	turn := Turn{
+		Guess:           guess,
+		Hint:            hint.String(),
+		RemainingBefore: len(g.dictionary),
+		Entropy:         g.entropy(guess),
 	}
+	turn.Gap, _ = g.gap()
 
-	best, bestEntropy := "", 0.0
+	if g.scores != nil {
+		turn.TopGuesses = g.BestGuesses(turnTopGuesses)
+	}
 
-	for guessIndex, potentialGuess := range g.dictionary {
-		info := workerPool.calculateEntropy(potentialGuess, g.dictionary)
-		if Verbose {
-			fmt.Printf("(%v/%v) %v: %v\n", guessIndex+1, len(g.dictionary), potentialGuess, info)
+	turn.Buckets = map[string]int{}
+	for h, size := range bucket(guess, g.dictionary) {
+		turn.Buckets[h.String()] = size
+	}
+
+	g.narrow(Constraint{
+		hint: hint,
+		word: guess,
+	})
+	g.knowledge.add(guess, hint)
+
+	turn.RemainingAfter = len(g.dictionary)
+	g.turns = append(g.turns, turn)
+
+	g.dump()
+}
+
+// narrow removes the words which don't satisfy f from the possible answers and guesses.
+func (g *Game) narrow(f wordFilter) {
+	g.dictionary = filterWords(f, g.dictionary)
+	g.guessOnly = filterWords(f, g.guessOnly)
+	g.version = newDictionaryVersion()
+	g.scores = nil
+	g.filters = append(g.filters, f)
+}
+
+// recover ignores the most suspicious hint other than the latest one, so that there are possible answers again. A hint
+// ignored by an earlier recovery is reconsidered, since later hints may show it was right after all. It returns the
+// turn of the ignored hint. If ignoring any one hint doesn't leave possible answers, it returns false. See
+// GameOptions.Recover.
+func (g *Game) recover() (Turn, bool) {
+	suspect, fewest := -1, 0
+
+	for i, f := range g.filters[:len(g.filters)-1] {
+		if _, ok := f.(Constraint); !ok {
+			continue
+		}
+
+		dictionary := g.startDictionary
+		for j, other := range g.filters {
+			if j != i {
+				dictionary = filterWords(other, dictionary)
+			}
+		}
+
+		// When tied, the later hint is ignored.
+		if len(dictionary) != 0 && (suspect == -1 || len(dictionary) <= fewest) {
+			suspect, fewest = i, len(dictionary)
 		}
+	}
+
+	if suspect == -1 {
+		return Turn{}, false
+	}
+
+	// Rather than replaying every remaining filter, fold them into knowledge and filter once. Only hints knowledge can't
+	// hold exactly have to be replayed on top of that.
+	g.knowledge = newKnowledge()
+	var inexact []wordFilter
+
+	for i, f := range g.filters {
+		if i == suspect {
+			continue
+		}
+
+		switch f := f.(type) {
+		case Constraint:
+			g.knowledge.add(f.word, f.hint)
+
+			if !foldsExactly(f.word, f.hint) {
+				inexact = append(inexact, f)
+			}
+		case CountConstraint:
+			g.knowledge.addCount(f)
+		}
+	}
+
+	g.dictionary = filterWords(g.knowledge, g.startDictionary)
+	g.guessOnly = filterWords(g.knowledge, g.startGuessOnly)
+
+	for _, f := range inexact {
+		g.dictionary = filterWords(f, g.dictionary)
+		g.guessOnly = filterWords(f, g.guessOnly)
+	}
+
+	g.version = newDictionaryVersion()
+	g.scores = nil
+
+	c := g.filters[suspect].(Constraint)
+	return Turn{Guess: c.word, Hint: c.hint.String()}, true
+}
 
-		if info > bestEntropy {
-			best = potentialGuess
-			bestEntropy = info
+// Apply narrows down the possible answers using the hint guess resulted in, as if the turn was played. It returns the
+// information the hint revealed in bits (see InfoGained) and the number of possible answers left.
+//
+// It returns an error, leaving the game unchanged, if the guess or hint are invalid, or if no answers would be left.
+func (g *Game) Apply(guess, hint string) (float64, int, error) {
+	c, err := NewConstraint(guess, hint)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if c.filterNum(g.dictionary) == 0 {
+		return 0, 0, fmt.Errorf("guess %v with hint %v leaves no possible answers", guess, hint)
+	}
+
+	before := len(g.dictionary)
+	g.apply(guess, c.hint)
+
+	return InfoGained(before, len(g.dictionary)), len(g.dictionary), nil
+}
+
+// A BoardRow is a row of a Wordle board: a guess and the hint it resulted in, e.g. as read from a screenshot. The hint
+// uses the same format as Turn.Hint.
+type BoardRow struct {
+	Guess string
+	Hint  string
+}
+
+// ApplyBoard applies each row of a board in order, as if by Game.Apply. It returns the possible answers left.
+//
+// It returns an error, leaving the game unchanged, if any row can't be applied.
+func (g *Game) ApplyBoard(rows []BoardRow) ([]string, error) {
+	before := *g
+
+	for i, row := range rows {
+		if _, _, err := g.Apply(row.Guess, row.Hint); err != nil {
+			*g = before
+			return nil, fmt.Errorf("row %v: %w", i+1, err)
 		}
 	}
 
-	return best, bestEntropy
+	return append([]string(nil), g.dictionary...), nil
+}
+
+// Replay applies each of turns in order, as if by Game.Apply, e.g. to resume a game from a transcript saved part way
+// through it. Game.Play then continues from the next turn.
+//
+// It returns an error, leaving the game unchanged, if any turn can't be applied.
+func (g *Game) Replay(turns []Turn) error {
+	before := *g
+
+	for i, turn := range turns {
+		if _, _, err := g.Apply(turn.Guess, turn.Hint); err != nil {
+			*g = before
+			return fmt.Errorf("turn %v: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// ApplyCountConstraint narrows down the possible answers to those with the number of copies of a letter c allows. It
+// returns the number of possible answers left. Unlike Game.Apply, no turn is recorded.
+//
+// It returns an error, leaving the game unchanged, if c is invalid or if no answers would be left.
+func (g *Game) ApplyCountConstraint(c CountConstraint) (int, error) {
+	if err := c.validate(); err != nil {
+		return 0, fmt.Errorf("bad count constraint: %w", err)
+	}
+
+	if len(filterWords(c, g.dictionary)) == 0 {
+		return 0, fmt.Errorf("%v to %v copies of %c leaves no possible answers", c.Min, c.Max, c.Letter)
+	}
+
+	g.narrow(c)
+	g.knowledge.addCount(c)
+
+	return len(g.dictionary), nil
+}
+
+// BestGuess returns the best guess at this stage of the game, along with its entropy. See Game.getBestGuess for
+// details.
+func (g *Game) BestGuess() (string, float64) {
+	return g.getBestGuess(len(g.turns) == 0)
+}
+
+// The worker pool used to calculate the entropy of potential guesses.
+var workerPool = newEntropyWorkerPool(runtime.NumCPU())
+
+// getBestGuess returns the best guess to make at this stage of the game.
+//
+// It does so by choosing the word which will narrow down the number of potential answers the most. In other words, the
+// words which provides the most information. In other words: the words with the highest entropy.
+//
+// See hintEntropy for details on the entropy calculation.
+//
+// The first guess has no prior information, and thus is solely based on the dictionary of words.
+// It also takes the longest to compute. So, it's cached for each dictionary - see openers.
+func (g *Game) getBestGuess(firstGuess bool) (string, float64) {
+	return g.bestGuess(firstGuess, Verbose)
+}
+
+// bestGuess is getBestGuess, printing the score of each candidate if verbose is set.
+func (g *Game) bestGuess(firstGuess, verbose bool) (string, float64) {
+	if best, ok := g.lookupBestGuess(firstGuess); ok {
+		g.updateBest(best, 1)
+		return best.Word, best.Score
+	}
+
+	var key openerKey
+	if firstGuess {
+		key = newOpenerKey(g.dictionary, g.candidateGuessOnly())
+	}
+
+	start := time.Now()
+
+	endRegion := g.traceRegion(traceFirstGuess)
+	g.scoreCandidates(verbose)
+	endRegion()
+
+	best, ok := g.chooseGuess(g.scores)
+	if !ok {
+		// Every candidate is excluded, so fall back to the other allowed words.
+		best, _ = g.chooseGuess(g.score(g.allowed))
+	}
+
+	g.observe(MetricBestGuessSeconds, time.Since(start).Seconds())
+
+	// Only openers chosen purely by entropy are worth reusing in other games.
+	if firstGuess && ok && len(g.options.BlockedGuesses) == 0 && g.options.Lambda == nil && g.options.GuessBudget == 0 &&
+		g.options.TiePolicy == TieFirst && g.options.Rand == nil && g.options.Strategy == EntropyStrategy {
+		openers.set(key, best)
+	}
+
+	g.updateBest(best, 1)
+
+	return best.Word, best.Score
+}
+
+// lookupBestGuess returns the best guess at this stage of the game if it doesn't have to be calculated: if it's the
+// first guess and GameOptions.FirstGuess is set, if it's in GameOptions.DecisionTree, or if it's the first guess and
+// it's been cached (see openers).
+func (g *Game) lookupBestGuess(firstGuess bool) (ScoredGuess, bool) {
+	if firstGuess && g.options.FirstGuess != "" {
+		return ScoredGuess{Word: g.options.FirstGuess, Score: g.entropy(g.options.FirstGuess)}, true
+	}
+
+	// Both are chosen by entropy.
+	if g.options.Strategy != EntropyStrategy {
+		return ScoredGuess{}, false
+	}
+
+	if node := g.decisionNode(); node != nil {
+		return ScoredGuess{Word: node.Guess, Score: node.Entropy}, true
+	}
+
+	if firstGuess {
+		opener, ok := openers.get(newOpenerKey(g.dictionary, g.candidateGuessOnly()))
+		if ok && !g.options.BlockedGuesses[opener.Word] {
+			return opener, true
+		}
+	}
+
+	return ScoredGuess{}, false
+}
+
+// decisionNode returns the node of GameOptions.DecisionTree for this stage of the game, or nil if there's no tree or
+// the game has left it.
+func (g *Game) decisionNode() *DecisionNode {
+	node := g.options.DecisionTree
+
+	for _, turn := range g.turns {
+		if node == nil || node.Guess != turn.Guess {
+			return nil
+		}
+
+		node = node.Children[turn.Hint]
+	}
+
+	return node
+}
+
+// updateBest reports the best guess so far to GameOptions.OnBestUpdate, if set.
+func (g *Game) updateBest(best ScoredGuess, done float64) {
+	if g.options.OnBestUpdate != nil {
+		g.options.OnBestUpdate(best, done)
+	}
+}
+
+// entropy returns the entropy of guessing word at this stage of the game. See hintEntropy.
+func (g *Game) entropy(word string) float64 {
+	reference, version := g.reference()
+	return g.pool.calculateEntropy(word, reference, version)
+}
+
+// reference returns the dictionary entropy is calculated against, and its version. See GameOptions.EntropyReference.
+func (g *Game) reference() ([]string, uint64) {
+	if g.options.EntropyReference == OriginalFull {
+		return g.startDictionary, g.startVersion
+	}
+
+	return g.dictionary, g.version
+}
+
+// score returns the entropy of each of words at this stage of the game.
+func (g *Game) score(words []string) []ScoredGuess {
+	scores := make([]ScoredGuess, len(words))
+
+	for i, word := range words {
+		scores[i] = ScoredGuess{Word: word, Score: g.entropy(word)}
+	}
+
+	return scores
+}
+
+// candidates returns the words which can be the best guess at this stage of the game: the words consistent with the
+// hints revealed so far.
+func (g *Game) candidates() []string {
+	guessOnly := g.candidateGuessOnly()
+	if len(guessOnly) == 0 {
+		return g.dictionary
+	}
+
+	return append(append([]string(nil), g.dictionary...), guessOnly...)
+}
+
+// candidateGuessOnly returns the guess only words which can be the best guess: none if GameOptions.AnswersOnly is
+// set, or the ones consistent with the hints revealed so far otherwise.
+func (g *Game) candidateGuessOnly() []string {
+	if g.options.AnswersOnly {
+		return nil
+	}
+
+	return g.guessOnly
+}
+
+// isPossible returns whether word is one of the possible answers left.
+func (g *Game) isPossible(word string) bool {
+	for _, possible := range g.dictionary {
+		if possible == word {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scoreCandidates sets the scores of the game's candidates to their entropy, printing each one if verbose is set.
+func (g *Game) scoreCandidates(verbose bool) {
+	candidates := g.candidates()
+	g.scores = make([]ScoredGuess, len(candidates))
+
+	var best *ScoredGuess
+	for guessIndex, potentialGuess := range candidates {
+		endRegion := g.traceRegion(traceEntropy)
+		info := g.entropy(potentialGuess)
+		endRegion()
+
+		if verbose && g.options.EntropyAsWords {
+			fmt.Printf("(%v/%v) %v: %v (eliminates ~%.0f of %v words on average)\n", guessIndex+1, len(candidates), potentialGuess, info, wordsEliminated(info, len(g.dictionary)), len(g.dictionary))
+		} else if verbose {
+			fmt.Printf("(%v/%v) %v: %v\n", guessIndex+1, len(candidates), potentialGuess, info)
+		}
+
+		g.scores[guessIndex] = ScoredGuess{Word: potentialGuess, Score: info}
+
+		// The final best is reported once it's chosen, since choosing it may depend on every score.
+		done := float64(guessIndex+1) / float64(len(candidates))
+		if (best == nil || info > best.Score) && !g.excluded(potentialGuess) && done < 1 {
+			best = &g.scores[guessIndex]
+			g.updateBest(*best, done)
+		}
+
+		g.reportProgress(done)
+	}
+}
+
+// reportProgress reports the fraction of candidates scored so far to GameOptions.OnProgress and Game.progress, if set.
+func (g *Game) reportProgress(done float64) {
+	if g.options.OnProgress != nil {
+		g.options.OnProgress(done)
+	}
+
+	if g.progress != nil {
+		g.progress(done)
+	}
+}
+
+// The maximum difference in score between two guesses for them to be considered tied.
+const tieEpsilon = 1e-9
+
+// chooseGuess returns the best of the guesses, which are scored by entropy. The guess with the highest entropy is best,
+// unless GameOptions.Lambda or GameOptions.GuessBudget say otherwise. Ties are broken as configured by
+// GameOptions.TiePolicy. Excluded guesses (see Game.excluded) are never chosen; if every guess is excluded, it returns
+// false.
+func (g *Game) chooseGuess(scores []ScoredGuess) (ScoredGuess, bool) {
+	scores = g.withoutExcluded(scores)
+	if len(scores) == 0 {
+		return ScoredGuess{}, false
+	}
+
+	if g.options.Lambda != nil || g.options.GuessBudget != 0 {
+		scores = topK(scores, g.options.CandidateTopK)
+	}
+
+	value := g.guessValue()
+
+	values := make([]float64, len(scores))
+	best := 0
+	for i := range scores {
+		values[i] = value(scores[i])
+		if values[i] > values[best] {
+			best = i
+		}
+	}
+
+	policy := g.options.TiePolicy
+	if policy == TieFirst && g.options.Rand != nil {
+		policy = TieRandom
+	}
+
+	if policy == TieFirst {
+		return scores[best], true
+	}
+
+	tolerance := g.options.TieTolerance
+	if tolerance < tieEpsilon {
+		tolerance = tieEpsilon
+	}
+
+	var tied []ScoredGuess
+	for i, score := range scores {
+		if values[best]-values[i] <= tolerance {
+			tied = append(tied, score)
+		}
+	}
+
+	return g.breakTie(tied, policy), true
+}
+
+// breakTie returns the guess chosen from tied, which are tied for the best, according to policy.
+func (g *Game) breakTie(tied []ScoredGuess, policy TiePolicy) ScoredGuess {
+	chosen := tied[0]
+
+	switch policy {
+	case TieRandom:
+		chosen = tied[g.options.Rand.Intn(len(tied))]
+	case TieWeight:
+		for _, guess := range tied[1:] {
+			if g.options.Weights[guess.Word] > g.options.Weights[chosen.Word] {
+				chosen = guess
+			}
+		}
+	case TieAlphabetical:
+		for _, guess := range tied[1:] {
+			if guess.Word < chosen.Word {
+				chosen = guess
+			}
+		}
+	case TieScorer:
+		chosenScore := g.options.Scorer(chosen.Word)
+		for _, guess := range tied[1:] {
+			if score := g.options.Scorer(guess.Word); score > chosenScore {
+				chosen, chosenScore = guess, score
+			}
+		}
+	}
+
+	return chosen
+}
+
+// topK returns the k guesses in scores with the highest scores, in their original order. It returns all of scores if k
+// is zero or at least len(scores).
+func topK(scores []ScoredGuess, k int) []ScoredGuess {
+	if k == 0 || k >= len(scores) {
+		return scores
+	}
+
+	indices := make([]int, len(scores))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	sort.SliceStable(indices, func(i, j int) bool {
+		return scores[indices[i]].Score > scores[indices[j]].Score
+	})
+
+	indices = indices[:k]
+	sort.Ints(indices)
+
+	result := make([]ScoredGuess, k)
+	for i, index := range indices {
+		result[i] = scores[index]
+	}
+
+	return result
+}
+
+// withoutExcluded returns the guesses which aren't excluded from being chosen - see Game.excluded.
+func (g *Game) withoutExcluded(scores []ScoredGuess) []ScoredGuess {
+	if len(g.options.BlockedGuesses) == 0 && len(g.turns) == 0 {
+		return scores
+	}
+
+	var result []ScoredGuess
+	for _, score := range scores {
+		if !g.excluded(score.Word) {
+			result = append(result, score)
+		}
+	}
+
+	return result
+}
+
+// excluded returns whether word can't be chosen as the best guess: if it's blocked by GameOptions.BlockedGuesses, or
+// if it's already been guessed this game, since guessing it again wastes a turn.
+func (g *Game) excluded(word string) bool {
+	if g.options.BlockedGuesses[word] {
+		return true
+	}
+
+	for _, turn := range g.turns {
+		if turn.Guess == word {
+			return true
+		}
+	}
+
+	return false
+}
+
+// guessValue returns a function returning how valuable a guess is at this stage of the game, used to choose between
+// guesses. See GameOptions.Strategy, GameOptions.Lambda and GameOptions.GuessBudget.
+func (g *Game) guessValue() func(ScoredGuess) float64 {
+	value := func(guess ScoredGuess) float64 {
+		return guess.Score
+	}
+
+	if g.options.Strategy == MinimaxStrategy {
+		value = func(guess ScoredGuess) float64 {
+			return guaranteedInfo(guess.Word, g.dictionary)
+		}
+	}
+
+	if g.options.GuessBudget != 0 {
+		value = g.budgetValue(value)
+	}
+
+	if g.options.Lambda != nil {
+		value = g.lambdaValue(value)
+	}
+
+	return value
+}
+
+// budgetTieBreak scales the value of guesses which are as likely to win within GameOptions.GuessBudget, so that it
+// only decides between them: the probabilities of winning differ by at least 1/(remaining answers) unless Weights are
+// set, which is much more than the value of any guess scaled by it.
+const budgetTieBreak = 1e-6
+
+// budgetValue returns value blended with the information a guess is guaranteed to reveal, depending on how close the
+// game is to exceeding GameOptions.GuessBudget.
+//
+// The information needed to find the answer is log2(remaining answers). The most a guess can reveal is
+// log2(number of possible hints), so that's the most that can be revealed with the guesses left in the budget after
+// this one. The closer the information needed is to that, the more the guaranteed information is favored.
+//
+// On the last two guesses of the budget, it's too late for information to help: what matters is the probability of
+// winning in time. On the last guess, that's the probability of guessing the answer. On the one before, it's that plus
+// the probability of guessing the answer next, by guessing the most likely answer left by the hint. Guesses which are as
+// likely to win are still ranked by value. Once the budget is used up, it no longer matters, so value is returned as is.
+func (g *Game) budgetValue(value func(ScoredGuess) float64) func(ScoredGuess) float64 {
+	guessesLeft := g.options.GuessBudget - len(g.turns) - 1
+	if guessesLeft < 0 {
+		return value
+	}
+
+	if guessesLeft <= 1 {
+		probabilities := make(map[string]float64, len(g.dictionary))
+		for _, candidate := range g.CandidateProbabilities() {
+			probabilities[candidate.Word] = candidate.Score
+		}
+
+		return func(guess ScoredGuess) float64 {
+			win := 0.0
+			mostLikely := map[wordHint]float64{}
+
+			for _, word := range g.dictionary {
+				hint := createHint(guess.Word, word)
+
+				switch {
+				case hint.solved():
+					win += probabilities[word]
+				case guessesLeft == 1 && probabilities[word] > mostLikely[hint]:
+					mostLikely[hint] = probabilities[word]
+				}
+			}
+
+			for _, probability := range mostLikely {
+				win += probability
+			}
+
+			return win + budgetTieBreak*value(guess)
+		}
+	}
+
+	needed := math.Log2(float64(len(g.dictionary)))
+
+	weight := 1.0
+	if guessesLeft > 0 {
+		weight = math.Min(1, needed/(float64(guessesLeft)*math.Log2(float64(numPossibleWordHints))))
+	}
+
+	return func(guess ScoredGuess) float64 {
+		return (1-weight)*value(guess) + weight*guaranteedInfo(guess.Word, g.dictionary)
+	}
+}
+
+// lambdaValue returns value plus the bonus for guesses which can win outright described by GameOptions.Lambda.
+func (g *Game) lambdaValue(value func(ScoredGuess) float64) func(ScoredGuess) float64 {
+	possible := make(map[string]bool, len(g.dictionary))
+	for _, word := range g.dictionary {
+		possible[word] = true
+	}
+
+	lambda := g.options.Lambda(len(g.dictionary))
+	winProbability := 1 / float64(len(g.dictionary))
+
+	return func(guess ScoredGuess) float64 {
+		if !possible[guess.Word] {
+			return value(guess)
+		}
+
+		return value(guess) + lambda*winProbability
+	}
+}
+
+// BestGuesses returns up to n of the best guesses at this stage of the game, best first. Each guess is scored by its
+// entropy.
+//
+// Scores are reused from the last best guess calculation if possible. Otherwise every candidate is scored, which
+// takes a long time for large dictionaries (e.g. ValidWords before any guesses are made).
+func (g *Game) BestGuesses(n int) []ScoredGuess {
+	if g.scores == nil {
+		g.scoreCandidates(false)
+	}
+
+	result := append([]ScoredGuess(nil), g.withoutExcluded(g.scores)...)
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+
+	if len(result) > n {
+		result = result[:n]
+	}
+
+	return result
+}
+
+// MeanCandidateEntropy returns the mean entropy of guessing each of the possible answers left at this stage of the
+// game. It's a measure of how much information is still easy to come by: it's low when most possible answers would
+// reveal little about the others, e.g. when they only differ by one letter.
+func (g *Game) MeanCandidateEntropy() float64 {
+	sum := 0.0
+	for _, score := range g.ScoreGuesses(g.dictionary) {
+		sum += score.Score
+	}
+
+	return sum / float64(len(g.dictionary))
+}
+
+// gap returns how much more entropy the best guess at this stage of the game has than the runner-up. It's only known if
+// every candidate has been scored, e.g. not when the cached first guess was used, and if there are at least two
+// candidates; otherwise it returns false.
+func (g *Game) gap() (float64, bool) {
+	if g.scores == nil {
+		return 0, false
+	}
+
+	top := g.BestGuesses(2)
+	if len(top) < 2 {
+		return 0, false
+	}
+
+	return top[0].Score - top[1].Score, true
+}
+
+// CandidateProbabilities returns every possible answer along with the probability of it being the answer, from most
+// to least likely. The probabilities come from GameOptions.Weights if set, and otherwise each possible answer is
+// equally likely.
+func (g *Game) CandidateProbabilities() []ScoredGuess {
+	result := make([]ScoredGuess, len(g.dictionary))
+
+	total := 0.0
+	for i, word := range g.dictionary {
+		result[i] = ScoredGuess{Word: word, Score: g.options.Weights[word]}
+		total += result[i].Score
+	}
+
+	for i := range result {
+		if total == 0 {
+			result[i].Score = 1 / float64(len(result))
+		} else {
+			result[i].Score /= total
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+
+	return result
+}
+
+// ExpectedRemaining returns the number of possible answers expected to be left after guessing word at this stage of the
+// game. It's a more intuitive measure of a guess than entropy: lower is better.
+//
+// Guessing a word splits the remaining words into buckets - one for each hint the guess could yield. After guessing,
+// only the words in the bucket matching the actual hint remain. The bigger the bucket, the more likely it is to be the
+// one that remains, so the expected number of remaining words is the sum of size * (size / total) over all buckets.
+func (g *Game) ExpectedRemaining(word string) float64 {
+	total := float64(len(g.dictionary))
+
+	expectedRemaining := 0.0
+	for _, size := range bucket(word, g.dictionary) {
+		expectedRemaining += float64(size) * float64(size) / total
+	}
+
+	return expectedRemaining
+}
+
+// GuessOutcomeOdds returns the probability that guessing word at this stage of the game guesses the answer (winNow), and
+// the probability that it doesn't but leaves only one possible answer, so that the next guess is sure to (singletonNext).
+// The probabilities come from Game.CandidateProbabilities. It panics if word can't be a guess.
+func (g *Game) GuessOutcomeOdds(word string) (winNow, singletonNext float64) {
+	if err := checkWord(word); err != nil {
+		panic(fmt.Sprintf("bad guess %v: %v", word, err))
+	}
+
+	buckets := bucket(word, g.dictionary)
+
+	for _, candidate := range g.CandidateProbabilities() {
+		hint := createHint(word, candidate.Word)
+
+		switch {
+		case hint.solved():
+			winNow += candidate.Score
+		case buckets[hint] == 1:
+			singletonNext += candidate.Score
+		}
+	}
+
+	return winNow, singletonNext
+}
+
+// PositionEntropy returns, for each position, how uncertain its letter still is: the entropy in bits of the letter at
+// that position in the answer, with each possible answer as likely as Game.CandidateProbabilities says. A position
+// whose letter is known is 0, and the most it can be is log2(26), if every letter is equally likely.
+func (g *Game) PositionEntropy() [wordSize]float64 {
+	var probabilities [wordSize][26]float64
+	for _, candidate := range g.CandidateProbabilities() {
+		for i := 0; i < wordSize; i++ {
+			probabilities[i][candidate.Word[i]-'a'] += candidate.Score
+		}
+	}
+
+	var result [wordSize]float64
+	for i, letters := range probabilities {
+		for _, probability := range letters {
+			if probability > 0 {
+				result[i] -= probability * math.Log2(probability)
+			}
+		}
+
+		// Rounding can leave a known letter slightly uncertain.
+		if result[i] < tieEpsilon {
+			result[i] = 0
+		}
+	}
+
+	return result
+}
+
+// positionBars are the bars positionBar chooses between, from least to most uncertain.
+const positionBars = "▁▂▃▄▅▆▇█"
+
+// positionBar returns the entropy of each position (see Game.PositionEntropy) as a compact bar chart, one bar per
+// position, where the tallest bar is as uncertain as a position can be.
+func positionBar(entropies [wordSize]float64) string {
+	bars := []rune(positionBars)
+	most := math.Log2(26)
+
+	var result strings.Builder
+	for _, entropy := range entropies {
+		result.WriteRune(bars[int(math.Round(entropy/most*float64(len(bars)-1)))])
+	}
+
+	return result.String()
+}
+
+// GuessLetterStats returns, for each position of word, the probability that guessing it at this stage of the game
+// results in each letter hint there, indexed by LetterHint: Absent, Present and Correct. It shows what guessing word is
+// likely to reveal. The probabilities come from Game.CandidateProbabilities, so they sum to 1 (up to rounding) for each
+// position. It panics if word can't be a guess.
+func (g *Game) GuessLetterStats(word string) [wordSize][3]float64 {
+	if err := checkWord(word); err != nil {
+		panic(fmt.Sprintf("bad guess %v: %v", word, err))
+	}
+
+	var stats [wordSize][3]float64
+
+	for _, candidate := range g.CandidateProbabilities() {
+		for i, h := range createHint(word, candidate.Word) {
+			stats[i][h] += candidate.Score
+		}
+	}
+
+	return stats
+}
+
+// useless returns whether guessing word can't rule out any of the possible answers left, because it gives the same hint
+// for all of them. Guessing the only possible answer left isn't useless, since it wins.
+func (g *Game) useless(word string) bool {
+	return len(g.dictionary) > 1 && len(bucket(word, g.dictionary)) == 1
+}
+
+// explainGuess prints a sentence describing why guess is a good (or bad) guess, based on how it splits up the
+// remaining words. See Game.ExpectedRemaining.
+func (g *Game) explainGuess(guess, bestGuess string) {
+	buckets := bucket(guess, g.dictionary)
+	total := float64(len(g.dictionary))
+	expectedRemaining := g.ExpectedRemaining(guess)
+
+	reason := "because it"
+	if guess != bestGuess {
+		reason = fmt.Sprintf("(instead of the best guess %v), which", bestGuess)
+	}
+
+	fmt.Printf("Guessed %v %v splits the %v remaining words into %v buckets averaging %.1f words each, and is expected to eliminate ~%.0f of them.\n",
+		guess, reason, len(g.dictionary), len(buckets), total/float64(len(buckets)), total-expectedRemaining)
+}
+
+// ScoreGuess returns the entropy of guessing word at this stage of the game. It panics if word can't be a guess, e.g. if
+// it's the wrong size.
+func (g *Game) ScoreGuess(word string) float64 {
+	if err := checkWord(word); err != nil {
+		panic(fmt.Sprintf("bad guess %v: %v", word, err))
+	}
+
+	return g.entropy(word)
+}
+
+// ScoreGuesses returns the entropy of guessing each of words at this stage of the game, in the same order. It's faster
+// than calling Game.ScoreGuess for each word, since words are scored in parallel. It panics if any of words can't be a
+// guess.
+func (g *Game) ScoreGuesses(words []string) []ScoredGuess {
+	for _, word := range words {
+		if err := checkWord(word); err != nil {
+			panic(fmt.Sprintf("bad guess %v: %v", word, err))
+		}
+	}
+
+	result := make([]ScoredGuess, len(words))
+	reference, version := g.reference()
+	for i, entropy := range g.pool.calculateEntropies(words, reference, version) {
+		result[i] = ScoredGuess{Word: words[i], Score: entropy}
+	}
+
+	return result
+}
+
+// BestGuessAmong returns the best guess among candidates at this stage of the game, along with its entropy. Candidates
+// don't have to be possible answers - they're scored against the remaining possible answers.
+//
+// It panics if a candidate can't be a guess, e.g. if it's the wrong size. If every candidate is blocked or has already
+// been guessed, it returns an empty guess.
+func (g *Game) BestGuessAmong(candidates []string) (string, float64) {
+	for _, candidate := range candidates {
+		if err := checkWord(candidate); err != nil {
+			panic(fmt.Sprintf("bad candidate %v: %v", candidate, err))
+		}
+	}
+
+	best, _ := g.chooseGuess(g.score(candidates))
+	return best.Word, best.Score
+}
+
+// BestGuessWithLetter returns the best guess at this stage of the game which contains letter, along with its entropy,
+// e.g. to test whether a letter is in the answer. Like Game.BestGuess, only words consistent with the hints revealed so
+// far are considered. It panics if letter isn't a lower case letter. If no such word contains letter, or every one is
+// blocked or has already been guessed, it returns an empty guess.
+func (g *Game) BestGuessWithLetter(letter byte) (string, float64) {
+	if letter < 'a' || letter > 'z' {
+		panic(fmt.Sprintf("bad letter %q: must be a lower case letter", letter))
+	}
+
+	var candidates []string
+	for _, candidate := range g.candidates() {
+		if strings.IndexByte(candidate, letter) != -1 {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return g.BestGuessAmong(candidates)
+}
+
+// MaxCoverageGuess returns the allowed word which tests the most letters not in exclude (e.g. the letters guessed
+// already), along with how many it tests. It's a common way for people to choose their first two guesses. Words
+// testing as many new letters are ranked by how many of the possible answers have those letters, so that common
+// letters are preferred. Blocked words and words already guessed aren't returned.
+func (g *Game) MaxCoverageGuess(exclude map[byte]bool) (string, int) {
+	// The number of possible answers each letter is in.
+	var answers [alphabetSize]int
+	for _, word := range g.dictionary {
+		var seen letterSet
+		for i := 0; i < wordSize; i++ {
+			if !seen.contains(word[i]) {
+				seen |= 1 << (word[i] - 'a')
+				answers[word[i]-'a']++
+			}
+		}
+	}
+
+	best, bestNew, bestCommon := "", -1, -1
+	for _, word := range g.allowed {
+		if g.excluded(word) {
+			continue
+		}
+
+		var seen letterSet
+		newLetters, common := 0, 0
+		for i := 0; i < wordSize; i++ {
+			letter := word[i]
+			if seen.contains(letter) || exclude[letter] {
+				continue
+			}
+
+			seen |= 1 << (letter - 'a')
+			newLetters++
+			common += answers[letter-'a']
+		}
+
+		if newLetters > bestNew || (newLetters == bestNew && common > bestCommon) {
+			best, bestNew, bestCommon = word, newLetters, common
+		}
+	}
+
+	if best == "" {
+		return "", 0
+	}
+
+	return best, bestNew
+}
+
+// DistinguishingGuess returns a guess which is guaranteed to reveal the answer: each hint it can yield leaves at most
+// one possible answer. If there's no such guess, it returns false.
+//
+// Possible answers are checked first, since guessing one of them might also win the game outright.
+func (g *Game) DistinguishingGuess() (string, bool) {
+	for _, words := range [][]string{g.dictionary, g.allowed} {
+		for _, potentialGuess := range words {
+			if len(bucket(potentialGuess, g.dictionary)) == len(g.dictionary) {
+				return potentialGuess, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// Indistinguishable returns the groups of possible answers which no single guess can tell apart, because every allowed
+// guess (not counting GameOptions.BlockedGuesses) results in the same hint for each word in the group. Once it's down
+// to one of these groups, the answer can only be found by guessing. Groups are in alphabetical order, as are the words
+// in each, and words which can be told apart from every other possible answer aren't included.
+//
+// Guessing a possible answer tells it apart from the others, so groups only arise if their words can't be guessed.
+func (g *Game) Indistinguishable() [][]string {
+	var groups [][]string
+	if len(g.dictionary) > 1 {
+		groups = [][]string{append([]string(nil), g.dictionary...)}
+	}
+
+	for _, guess := range g.allowed {
+		if len(groups) == 0 {
+			break
+		}
+
+		if g.options.BlockedGuesses[guess] {
+			continue
+		}
+
+		var split [][]string
+		for _, group := range groups {
+			byHint := map[wordHint][]string{}
+			var hints []wordHint
+			for _, word := range group {
+				hint := createHint(guess, word)
+				if _, ok := byHint[hint]; !ok {
+					hints = append(hints, hint)
+				}
+				byHint[hint] = append(byHint[hint], word)
+			}
+
+			for _, hint := range hints {
+				if len(byHint[hint]) > 1 {
+					split = append(split, byHint[hint])
+				}
+			}
+		}
+
+		groups = split
+	}
+
+	for _, group := range groups {
+		sort.Strings(group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i][0] < groups[j][0]
+	})
+
+	return groups
+}
+
+// BestProbe returns the best guess at this stage of the game which can't be the answer, along with its entropy. This
+// includes words inconsistent with the hints revealed so far - while they can't win, they can reveal more than any
+// of the possible answers, e.g. when many possible answers only differ by one letter.
+//
+// With the default TiePolicy, of probes worth the same, the one sharing the most letters with the possible answers (see
+// LetterOverlap) is chosen, since its hints say the most about which of their letters are in the answer.
+//
+// Every allowed word is scored, which takes a long time for large dictionaries. If every allowed word is a possible
+// answer or blocked, it returns an empty guess.
+func (g *Game) BestProbe() (string, float64) {
+	possible := make(map[string]bool, len(g.dictionary))
+	for _, word := range g.dictionary {
+		possible[word] = true
+	}
+
+	var probes []string
+	overlap := map[string]int{}
+	for _, word := range g.allowed {
+		if possible[word] {
+			continue
+		}
+
+		probes = append(probes, word)
+		for _, answer := range g.dictionary {
+			overlap[word] += LetterOverlap(word, answer)
+		}
+	}
+
+	// The first of tied guesses is chosen by default.
+	sort.SliceStable(probes, func(i, j int) bool {
+		return overlap[probes[i]] > overlap[probes[j]]
+	})
+
+	best, _ := g.chooseGuess(g.score(probes))
+	return best.Word, best.Score
+}
+
+// ConfirmGuess returns the guess at this stage of the game which reveals the most about whether suspected is the
+// answer rather than one of the other possible answers, along with how much it reveals in bits. Guessing suspected
+// itself would confirm it outright, so it's not considered - unless it's the only possible answer left. Guesses which
+// reveal the same about suspected are ranked by their entropy.
+//
+// Whether the answer is suspected is a yes or no question, so at most binaryEntropy(1/remaining) bits can be revealed
+// about it. That's the case when no other possible answer would give suspected's hint. Otherwise, the bigger the group
+// of possible answers which would give the same hint as suspected would, the less is revealed.
+//
+// If suspected isn't a possible answer, or every other allowed word is blocked, it returns an empty guess.
+func (g *Game) ConfirmGuess(suspected string) (string, float64) {
+	if !g.isPossible(suspected) {
+		return "", 0
+	}
+
+	if len(g.dictionary) == 1 {
+		return suspected, 0
+	}
+
+	total := float64(len(g.dictionary))
+	prior := binaryEntropy(1 / total)
+
+	var best string
+	var bestInfo, bestEntropy float64
+	for _, word := range g.allowed {
+		if word == suspected || g.excluded(word) {
+			continue
+		}
+
+		buckets := bucket(word, g.dictionary)
+
+		entropy := 0.0
+		for _, size := range buckets {
+			probability := float64(size) / total
+			entropy += math.Log2(1/probability) * probability
+		}
+
+		// Once the hint suspected would give is revealed, it's one of the words in its group.
+		group := float64(buckets[createHint(word, suspected)])
+		info := prior - (group/total)*binaryEntropy(1/group)
+
+		if best == "" || info > bestInfo+tieEpsilon || (info > bestInfo-tieEpsilon && entropy > bestEntropy) {
+			best, bestInfo, bestEntropy = word, info, entropy
+		}
+	}
+
+	return best, bestInfo
+}
+
+// binaryEntropy returns the entropy, in bits, of a yes or no question where the answer is yes with probability p.
+func binaryEntropy(p float64) float64 {
+	if p <= 0 || p >= 1 {
+		return 0
+	}
+
+	return -p*math.Log2(p) - (1-p)*math.Log2(1-p)
 }
@@ -0,0 +1,1495 @@
+package wordle
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestTutorial(t *testing.T) {
+	dictionary := ValidWords[:500]
+	answer := dictionary[123]
+
+	g, err := NewGame(GameOptions{Answer: answer, Dictionary: dictionary, Tutorial: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result GameResult
+	output := captureOutput(t, func() {
+		result = g.Play()
+	})
+
+	for _, turn := range g.turns {
+		explanation := fmt.Sprintf("Guessed %v because it splits the %v remaining words into", turn.Guess,
+			turn.RemainingBefore)
+		if !strings.Contains(output, explanation) {
+			t.Errorf("tutorial doesn't explain %v splitting %v words:\n%v", turn.Guess, turn.RemainingBefore, output)
+		}
+
+		left := fmt.Sprintf("The hint %v left %v of the %v words.", turn.Hint, turn.RemainingAfter, turn.RemainingBefore)
+		if !strings.Contains(output, left) {
+			t.Errorf("tutorial doesn't say %q:\n%v", left, output)
+		}
+	}
+
+	if result.Answer != answer {
+		t.Errorf("answer = %v, want %v", result.Answer, answer)
+	}
+}
+
+func TestOneWordDictionary(t *testing.T) {
+	defer quiet()()
+
+	g, err := NewGame(GameOptions{Answer: "crane", Dictionary: []string{"crane"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := g.Play()
+	if result.Answer != "crane" || result.Guesses != 1 {
+		t.Errorf("got %v in %v guesses, want crane in 1", result.Answer, result.Guesses)
+	}
+}
+
+func TestEmptyDictionary(t *testing.T) {
+	if _, err := NewGame(GameOptions{Dictionary: []string{}}); err == nil {
+		t.Error("NewGame with an empty dictionary didn't return an error")
+	}
+}
+
+func TestFirstGuessIsAnswer(t *testing.T) {
+	defer quiet()()
+
+	g, err := NewGame(GameOptions{Answer: "cigar", Dictionary: ValidWords[:100], FirstGuess: "cigar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result := g.Play(); result.Guesses != 1 {
+		t.Errorf("guessing the answer first took %v guesses, want 1", result.Guesses)
+	}
+}
+
+func TestBestGuessAmong(t *testing.T) {
+	g, err := NewGame(GameOptions{Dictionary: ValidWords[:500]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	worse, better := "fuzzy", "tares"
+	if g.entropy(worse) >= g.entropy(better) {
+		t.Fatalf("%v doesn't have more entropy than %v", better, worse)
+	}
+
+	guess, entropy := g.BestGuessAmong([]string{worse, better})
+	if guess != better || entropy != g.entropy(better) {
+		t.Errorf("BestGuessAmong = %v (%v), want %v (%v)", guess, entropy, better, g.entropy(better))
+	}
+}
+
+func TestBestGuessAmongBadCandidate(t *testing.T) {
+	g, err := NewGame(GameOptions{Dictionary: ValidWords[:10]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("BestGuessAmong with a candidate of the wrong size didn't panic")
+		}
+	}()
+
+	g.BestGuessAmong([]string{"tares", "tar"})
+}
+
+func TestDistinguishingGuess(t *testing.T) {
+	g, err := NewGame(GameOptions{Dictionary: []string{"bills", "fills", "hills"}, Guesses: []string{"fable"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if guess, ok := g.DistinguishingGuess(); !ok || guess != "fable" {
+		t.Errorf("DistinguishingGuess() = %v, %v, want fable, true", guess, ok)
+	}
+
+	g, err = NewGame(GameOptions{Dictionary: []string{"bills", "fills", "hills", "pills"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if guess, ok := g.DistinguishingGuess(); ok {
+		t.Errorf("DistinguishingGuess() = %v, but no guess tells the answers apart", guess)
+	}
+}
+
+// tiedDictionary is a dictionary where every word is tied for the best guess.
+var tiedDictionary = []string{"bills", "fills", "hills", "mills", "pills", "sills", "tills", "wills"}
+
+func TestRandBreaksTies(t *testing.T) {
+	defer quiet()()
+
+	// The first guess is cached once calculated, and then always used as is, so the tie is after it.
+	bestGuess := func(options GameOptions) string {
+		options.Dictionary = tiedDictionary
+
+		g, err := NewGame(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := g.Apply("crane", "bbbbb"); err != nil {
+			t.Fatal(err)
+		}
+
+		guess, _ := g.BestGuess()
+		return guess
+	}
+
+	if guess := bestGuess(GameOptions{}); guess != tiedDictionary[0] {
+		t.Errorf("without Rand, the best guess is %v, want the first tied guess %v", guess, tiedDictionary[0])
+	}
+
+	chosen := map[string]bool{}
+	for seed := int64(1); seed <= 20; seed++ {
+		guess := bestGuess(GameOptions{Rand: rand.New(rand.NewSource(seed))})
+		if again := bestGuess(GameOptions{Rand: rand.New(rand.NewSource(seed))}); again != guess {
+			t.Errorf("seed %v chose %v, then %v", seed, guess, again)
+		}
+
+		chosen[guess] = true
+	}
+
+	if len(chosen) < 2 {
+		t.Errorf("every seed chose the same guess: %v", chosen)
+	}
+}
+
+func TestTiePolicy(t *testing.T) {
+	defer quiet()()
+
+	// Every word is tied, and they're out of alphabetical order.
+	dictionary := []string{"tills", "mills", "bills", "wills", "fills"}
+
+	bestGuess := func(options GameOptions) string {
+		options.Dictionary = dictionary
+
+		g, err := NewGame(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The first guess is cached once calculated, and then always used as is, so the tie is after it.
+		if _, _, err := g.Apply("crane", "bbbbb"); err != nil {
+			t.Fatal(err)
+		}
+
+		guess, _ := g.BestGuess()
+		return guess
+	}
+
+	tests := []struct {
+		options GameOptions
+		want    string
+	}{
+		{GameOptions{TiePolicy: TieFirst}, "tills"},
+		{GameOptions{TiePolicy: TieAlphabetical}, "bills"},
+		{GameOptions{TiePolicy: TieWeight, Weights: map[string]float64{"tills": 1, "mills": 5, "bills": 2, "wills": 1, "fills": 1}}, "mills"},
+		{GameOptions{TiePolicy: TieScorer, Scorer: func(word string) float64 {
+			if word == "wills" {
+				return 1
+			}
+			return 0
+		}}, "wills"},
+	}
+
+	for _, test := range tests {
+		if got := bestGuess(test.options); got != test.want {
+			t.Errorf("tie policy %v chose %v, want %v", test.options.TiePolicy, got, test.want)
+		}
+	}
+
+	chosen := map[string]bool{}
+	for seed := int64(1); seed <= 20; seed++ {
+		guess := bestGuess(GameOptions{TiePolicy: TieRandom, Rand: rand.New(rand.NewSource(seed))})
+		if again := bestGuess(GameOptions{TiePolicy: TieRandom, Rand: rand.New(rand.NewSource(seed))}); again != guess {
+			t.Errorf("seed %v chose %v, then %v", seed, guess, again)
+		}
+
+		chosen[guess] = true
+	}
+
+	if len(chosen) < 2 {
+		t.Errorf("every seed chose the same guess: %v", chosen)
+	}
+
+	if _, err := NewGame(GameOptions{Dictionary: dictionary, TiePolicy: TieScorer}); err == nil {
+		t.Error("breaking ties by score without a Scorer didn't return an error")
+	}
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	g, err := NewGame(GameOptions{Dictionary: ValidWords[:500]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone := g.Clone()
+	_, remaining, err := clone.Apply("tares", "bbbbb")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(g.dictionary) != 500 || len(g.turns) != 0 {
+		t.Errorf("applying a hint to the clone left the original with %v possible answers and %v turns",
+			len(g.dictionary), len(g.turns))
+	}
+
+	if remaining == 500 || len(clone.dictionary) != remaining {
+		t.Errorf("applying a hint to the clone left %v possible answers, want it narrowed down", len(clone.dictionary))
+	}
+}
+
+func BenchmarkGetBestGuessMidGame(b *testing.B) {
+	defer quiet()()
+
+	g, err := NewGame(GameOptions{})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	if _, _, err := g.Apply("tares", "bbbbb"); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		// A new version of the dictionary, so that nothing calculated by earlier iterations is reused.
+		b.StopTimer()
+		clone := g.Clone()
+		clone.version = newDictionaryVersion()
+		b.StartTimer()
+
+		clone.getBestGuess(false)
+	}
+}
+
+func TestLambdaShiftsToWinning(t *testing.T) {
+	// Exploit once few answers are left, explore otherwise.
+	lambda := func(remaining int) float64 {
+		if remaining <= 3 {
+			return 10
+		}
+
+		return 0
+	}
+
+	bestGuess := func(dictionary []string) string {
+		// bhfpx tells every answer apart, but can't be the answer.
+		g, err := NewGame(GameOptions{Dictionary: dictionary, Guesses: []string{"bhfpx"}, Lambda: lambda})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The cached first guess is always used as is, so choose the second guess.
+		if _, _, err := g.Apply("zzzzz", "bbbbb"); err != nil {
+			t.Fatal(err)
+		}
+
+		guess, _ := g.bestGuess(false, false)
+		return guess
+	}
+
+	if guess := bestGuess([]string{"bills", "fills", "hills", "pills"}); guess != "bhfpx" {
+		t.Errorf("early on, the best guess is %v, want bhfpx", guess)
+	}
+
+	if guess := bestGuess([]string{"bills", "fills", "hills"}); guess != "bills" {
+		t.Errorf("late in the game, the best guess is %v, want the possible answer bills", guess)
+	}
+}
+
+func TestBestProbe(t *testing.T) {
+	cluster := []string{"bound", "found", "hound", "mound", "pound", "round", "sound", "wound"}
+
+	g, err := NewGame(GameOptions{Dictionary: cluster, Guesses: []string{"chomp", "frown", "whips"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	probe, entropy := g.BestProbe()
+	if probe != "whips" {
+		t.Errorf("best probe is %v, want whips", probe)
+	}
+
+	for _, candidate := range cluster {
+		if g.entropy(candidate) >= entropy {
+			t.Errorf("guessing %v reveals as much as probing with %v", candidate, probe)
+		}
+	}
+}
+
+func TestBestProbeOverlap(t *testing.T) {
+	dictionary := []string{"bills", "fills", "hills", "jills"}
+
+	// Both probes tell every possible answer apart, but fhjls also tests the letters they share.
+	g, err := NewGame(GameOptions{Dictionary: dictionary, Guesses: []string{"fhjxz", "fhjls"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if LetterOverlap("fhjls", "bills") != 2 || LetterOverlap("fhjxz", "bills") != 0 {
+		t.Fatal("fhjls doesn't overlap with bills more than fhjxz does")
+	}
+
+	if probe, entropy := g.BestProbe(); probe != "fhjls" || entropy != 2 {
+		t.Errorf("best probe is %v (entropy %v), want fhjls (entropy 2)", probe, entropy)
+	}
+}
+
+func TestSameRandPlaysSameGame(t *testing.T) {
+	play := func(seed int64) GameResult {
+		r := rand.New(rand.NewSource(seed))
+
+		// The first guess is fixed, since calculating it for every game would be slow.
+		result, err := Solve(GameOptions{Answer: RandomAnswer(r), Dictionary: Answers, FirstGuess: "crane", Rand: r})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return result
+	}
+
+	for seed := int64(1); seed <= 5; seed++ {
+		first, second := play(seed), play(seed)
+
+		if first.Answer != second.Answer || len(first.Turns) != len(second.Turns) {
+			t.Fatalf("seed %v solved %v in %v, then %v in %v", seed, first.Answer, first.Guesses, second.Answer,
+				second.Guesses)
+		}
+
+		for i := range first.Turns {
+			if first.Turns[i].Guess != second.Turns[i].Guess {
+				t.Errorf("seed %v: guess %v was %v, then %v", seed, i+1, first.Turns[i].Guess, second.Turns[i].Guess)
+			}
+		}
+	}
+}
+
+func TestBlockedGuesses(t *testing.T) {
+	dictionary := ValidWords[:300]
+
+	g, err := NewGame(GameOptions{Dictionary: dictionary})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	top := g.BestGuesses(2)
+	if top[0].Score == top[1].Score {
+		t.Fatalf("the best guesses %v are tied", top)
+	}
+
+	g, err = NewGame(GameOptions{Dictionary: dictionary, BlockedGuesses: map[string]bool{top[0].Word: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if guess, _ := g.bestGuess(true, false); guess != top[1].Word {
+		t.Errorf("with %v blocked, the best guess is %v, want the next best %v", top[0].Word, guess, top[1].Word)
+	}
+
+	if !g.isPossible(top[0].Word) {
+		t.Errorf("blocked %v isn't a possible answer", top[0].Word)
+	}
+}
+
+func TestBlockedGuessesMustBeWords(t *testing.T) {
+	if _, err := NewGame(GameOptions{Dictionary: ValidWords[:10], BlockedGuesses: map[string]bool{"zzzzz": true}}); err == nil {
+		t.Error("blocking a word which isn't in the dictionary didn't return an error")
+	}
+}
+
+func TestGuessBudgetWinsMoreWithinBudget(t *testing.T) {
+	dictionary := Answers[:1000]
+	const budget = 3
+
+	wins := func(options GameOptions) int {
+		options.Dictionary = dictionary
+
+		won := 0
+		for _, result := range SolveBatch(options, dictionary, 1) {
+			if result.Guesses <= budget {
+				won++
+			}
+		}
+
+		return won
+	}
+
+	plain, budgeted := wins(GameOptions{}), wins(GameOptions{GuessBudget: budget})
+	t.Logf("won within %v guesses: %v plain, %v with a budget", budget, plain, budgeted)
+
+	if budgeted <= plain {
+		t.Errorf("with a guess budget of %v, %v games were won within it, but %v without", budget, budgeted, plain)
+	}
+}
+
+func TestCandidateProbabilitiesUniform(t *testing.T) {
+	g, err := NewGame(GameOptions{Dictionary: ValidWords[:40]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	probabilities := g.CandidateProbabilities()
+	if len(probabilities) != 40 {
+		t.Fatalf("got %v probabilities, want 40", len(probabilities))
+	}
+
+	total := 0.0
+	for _, candidate := range probabilities {
+		total += candidate.Score
+
+		if math.Abs(candidate.Score-1.0/40) > 1e-12 {
+			t.Errorf("probability of %v is %v, want 1/40", candidate.Word, candidate.Score)
+		}
+	}
+
+	if math.Abs(total-1) > 1e-12 {
+		t.Errorf("probabilities sum to %v, want 1", total)
+	}
+}
+
+func TestCandidateProbabilitiesWeighted(t *testing.T) {
+	g, err := NewGame(GameOptions{
+		Dictionary: []string{"bills", "fills", "hills", "pills"},
+		Weights:    map[string]float64{"bills": 1, "fills": 3, "hills": 6},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ScoredGuess{{Word: "hills", Score: 0.6}, {Word: "fills", Score: 0.3}, {Word: "bills", Score: 0.1},
+		{Word: "pills", Score: 0}}
+
+	probabilities := g.CandidateProbabilities()
+	if len(probabilities) != len(want) {
+		t.Fatalf("CandidateProbabilities() = %v, want %v", probabilities, want)
+	}
+
+	for i := range want {
+		if probabilities[i].Word != want[i].Word || math.Abs(probabilities[i].Score-want[i].Score) > 1e-12 {
+			t.Errorf("CandidateProbabilities() = %v, want %v", probabilities, want)
+			break
+		}
+	}
+}
+
+func TestShowEliminated(t *testing.T) {
+	dictionary := ValidWords[:500]
+	answer := dictionary[321]
+
+	g, err := NewGame(GameOptions{Answer: answer, Dictionary: dictionary, ShowEliminated: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := captureOutput(t, func() {
+		g.Play()
+	})
+
+	for i, turn := range g.turns {
+		prefix := fmt.Sprintf("(Guess #%v) Eliminated: ", i+1)
+
+		start := strings.Index(output, prefix)
+		if start == -1 {
+			t.Errorf("no eliminated words shown for guess %v:\n%v", i+1, output)
+			continue
+		}
+
+		line := output[start+len(prefix):]
+		line = line[:strings.IndexByte(line, '\n')]
+
+		c, err := NewConstraint(turn.Guess, turn.Hint)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, word := range strings.Split(line, ", ") {
+			if c.Satisfies(word) {
+				t.Errorf("%v was shown as eliminated by guess %v, but satisfies its hint", word, i+1)
+			}
+		}
+	}
+}
+
+func TestApplyBoard(t *testing.T) {
+	g, err := NewGame(GameOptions{Dictionary: Answers})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := []BoardRow{
+		{Guess: "tares", Hint: createHint("tares", "moist").String()},
+		{Guess: "colin", Hint: createHint("colin", "moist").String()},
+		{Guess: "build", Hint: createHint("build", "moist").String()},
+	}
+
+	want := Answers
+	for _, row := range rows {
+		c, err := NewConstraint(row.Guess, row.Hint)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want = c.Filter(want)
+	}
+
+	remaining, err := g.ApplyBoard(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(remaining, ",") != strings.Join(want, ",") || len(g.turns) != 3 {
+		t.Errorf("ApplyBoard left %v after %v turns, want %v after 3", remaining, len(g.turns), want)
+	}
+}
+
+func TestApplyBoardBadRow(t *testing.T) {
+	g, err := NewGame(GameOptions{Dictionary: Answers})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = g.ApplyBoard([]BoardRow{{Guess: "tares", Hint: "bbbbb"}, {Guess: "colin", Hint: "bbbbx"}})
+	if err == nil || !strings.HasPrefix(err.Error(), "row 2: ") {
+		t.Errorf("ApplyBoard with a bad second row returned %v, want an error for row 2", err)
+	}
+
+	if len(g.dictionary) != len(Answers) || len(g.turns) != 0 {
+		t.Errorf("ApplyBoard with a bad row changed the game")
+	}
+}
+
+func TestSolvedGameConfidence(t *testing.T) {
+	result, err := Solve(GameOptions{Answer: "moist", Dictionary: Answers[:500]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Confidence != 1 {
+		t.Errorf("confidence of a solved game is %v, want 1", result.Confidence)
+	}
+}
+
+func TestShowConfidence(t *testing.T) {
+	defer quiet()()
+
+	result, output := playInput(t, GameOptions{Dictionary: []string{"bills", "fills"}, ShowConfidence: true},
+		"bills\nbgggg\n")
+
+	if result.Confidence != 1 || !strings.Contains(output, "fills (confidence: 100.0%)") {
+		t.Errorf("confidence of the only word left is %v, printed as:\n%v", result.Confidence, output)
+	}
+}
+
+func TestExpectedRemaining(t *testing.T) {
+	g, err := NewGame(GameOptions{Dictionary: ValidWords[:300]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	size := float64(len(g.dictionary))
+	for _, word := range []string{"tares", "crane", "fuzzy", "eerie"} {
+		expected := g.ExpectedRemaining(word)
+
+		squares := 0.0
+		for _, bucketSize := range bucket(word, g.dictionary) {
+			squares += float64(bucketSize * bucketSize)
+		}
+
+		if want := squares / size; math.Abs(expected-want) > 1e-9 {
+			t.Errorf("ExpectedRemaining(%v) = %v, want %v", word, expected, want)
+		}
+
+		// Entropy is the log of a geometric mean of bucket sizes, which is never more than their arithmetic mean.
+		if fromEntropy := size / math.Pow(2, g.entropy(word)); fromEntropy > expected+1e-9 {
+			t.Errorf("ExpectedRemaining(%v) = %v, less than %v from its entropy", word, expected, fromEntropy)
+		}
+	}
+
+	// When every bucket is the same size, they're equal.
+	g, err = NewGame(GameOptions{Dictionary: []string{"bills", "fills"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected, fromEntropy := g.ExpectedRemaining("bills"), 2/math.Pow(2, g.entropy("bills")); expected != 1 ||
+		fromEntropy != 1 {
+		t.Errorf("ExpectedRemaining(bills) = %v and %v from its entropy, want 1", expected, fromEntropy)
+	}
+}
+
+func TestApplyInfoGained(t *testing.T) {
+	g, err := NewGame(GameOptions{Dictionary: Answers})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bits, remaining, err := g.Apply("tares", createHint("tares", "moist").String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := math.Log2(float64(len(Answers)) / float64(remaining)); bits != want || remaining != len(g.dictionary) {
+		t.Errorf("Apply returned %v bits and %v left, want %v bits and %v", bits, remaining, want, len(g.dictionary))
+	}
+
+	// A hint which leaves nothing is an error.
+	if _, _, err := g.Apply("moist", "bbbbb"); err == nil {
+		t.Error("applying a hint which leaves no possible answers didn't return an error")
+	}
+}
+
+func TestOnBestUpdate(t *testing.T) {
+	defer quiet()()
+
+	var updates []ScoredGuess
+	var fractions []float64
+	g, err := NewGame(GameOptions{
+		Dictionary: ValidWords[:300],
+		OnBestUpdate: func(best ScoredGuess, done float64) {
+			updates = append(updates, best)
+			fractions = append(fractions, done)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := g.Apply("zzzzz", "bbbbb"); err != nil {
+		t.Fatal(err)
+	}
+	updates, fractions = nil, nil
+
+	guess, score := g.BestGuess()
+	if len(updates) == 0 {
+		t.Fatal("no best guess updates")
+	}
+
+	if last := updates[len(updates)-1]; last.Word != guess || last.Score != score {
+		t.Errorf("last update is %v (%v), best guess is %v (%v)", last.Word, last.Score, guess, score)
+	}
+
+	if fractions[len(fractions)-1] != 1 {
+		t.Errorf("last update is %v done, want 1", fractions[len(fractions)-1])
+	}
+
+	for i := 1; i < len(updates); i++ {
+		if fractions[i] < fractions[i-1] || updates[i].Score < updates[i-1].Score {
+			t.Errorf("update %v (%v at %v) is worse or earlier than the one before it (%v at %v)", i, updates[i],
+				fractions[i], updates[i-1], fractions[i-1])
+		}
+	}
+}
+
+func TestNonLetterDictionaryWord(t *testing.T) {
+	dictionary := []string{"crane", "ab1cd", "moist"}
+
+	if _, err := NewGame(GameOptions{Dictionary: dictionary}); !errors.Is(err, ErrInvalidWordChar) {
+		t.Errorf("dictionary with ab1cd returned error %v, want an invalid word character error", err)
+	}
+
+	if _, err := NewGame(GameOptions{Dictionary: Answers[:10], Guesses: dictionary}); !errors.Is(err, ErrInvalidWordChar) {
+		t.Errorf("guesses with ab1cd returned error %v, want an invalid word character error", err)
+	}
+
+	var g *Game
+	output := captureOutput(t, func() {
+		var err error
+		if g, err = NewGame(GameOptions{Dictionary: dictionary, SkipInvalidWords: true}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if !strings.Contains(output, `"ab1cd"`) {
+		t.Errorf("skipping ab1cd didn't warn about it:\n%v", output)
+	}
+
+	if fmt.Sprint(g.dictionary) != "[crane moist]" {
+		t.Errorf("dictionary skipping invalid words is %v, want [crane moist]", g.dictionary)
+	}
+}
+
+func TestConfirmGuess(t *testing.T) {
+	defer quiet()()
+
+	g, err := NewGame(GameOptions{Dictionary: ValidWords[:500]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := g.Apply("crane", createHint("crane", "moist").String()); err != nil {
+		t.Fatal(err)
+	}
+
+	best, _ := g.BestGuess()
+
+	const suspected = "booby"
+	guess, info := g.ConfirmGuess(suspected)
+	if guess == best || guess == suspected {
+		t.Fatalf("guess confirming %v is %v, want something other than it and the best guess %v", suspected, guess, best)
+	}
+
+	// The confirming guess tells suspected apart from every other possible answer, but the best guess doesn't.
+	if group := bucket(guess, g.dictionary)[createHint(guess, suspected)]; group != 1 {
+		t.Errorf("%v leaves %v possible answers if %v is the answer, want 1", guess, group, suspected)
+	}
+
+	if group := bucket(best, g.dictionary)[createHint(best, suspected)]; group == 1 {
+		t.Errorf("the best guess %v tells %v apart too", best, suspected)
+	}
+
+	if want := binaryEntropy(1 / float64(len(g.dictionary))); math.Abs(info-want) > tieEpsilon {
+		t.Errorf("guess confirming %v reveals %v bits, want %v", suspected, info, want)
+	}
+
+	if guess, _ := g.ConfirmGuess("tares"); guess != "" {
+		t.Errorf("guess confirming an impossible answer is %v, want none", guess)
+	}
+}
+
+func TestScoreGuessesMatchesScoreGuess(t *testing.T) {
+	defer quiet()()
+
+	g, err := NewGame(GameOptions{Dictionary: ValidWords[:1000]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := g.Apply("tares", "bbbbb"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Score them alone in a new version of the dictionary, so that nothing calculated together is reused.
+	alone := g.Clone()
+	alone.version = newDictionaryVersion()
+
+	words := append([]string{"fuzzy", "eerie", "tares"}, ValidWords[1000:1100]...)
+	scores := g.ScoreGuesses(words)
+	if len(scores) != len(words) {
+		t.Fatalf("scored %v guesses, want %v", len(scores), len(words))
+	}
+
+	for i, word := range words {
+		if scores[i].Word != word {
+			t.Errorf("score %v is for %v, want %v", i, scores[i].Word, word)
+		}
+
+		if want := alone.ScoreGuess(word); math.Abs(scores[i].Score-want) > 1e-12 {
+			t.Errorf("%v scored %v together, %v alone", word, scores[i].Score, want)
+		}
+	}
+}
+
+// benchmarkScoring benchmarks score scoring the first 200 words against the words left after guessing tares.
+func benchmarkScoring(b *testing.B, score func(g *Game, words []string)) {
+	defer quiet()()
+
+	g, err := NewGame(GameOptions{})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	if _, _, err := g.Apply("tares", "bbbbb"); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		// A new version of the dictionary, so that nothing calculated by earlier iterations is reused.
+		b.StopTimer()
+		clone := g.Clone()
+		clone.version = newDictionaryVersion()
+		b.StartTimer()
+
+		score(clone, ValidWords[:200])
+	}
+}
+
+func BenchmarkScoreGuesses(b *testing.B) {
+	benchmarkScoring(b, func(g *Game, words []string) {
+		g.ScoreGuesses(words)
+	})
+}
+
+func BenchmarkScoreGuessLoop(b *testing.B) {
+	benchmarkScoring(b, func(g *Game, words []string) {
+		for _, word := range words {
+			g.ScoreGuess(word)
+		}
+	})
+}
+
+func TestBestGuessNotRepeated(t *testing.T) {
+	defer quiet()()
+
+	g, err := NewGame(GameOptions{Dictionary: ValidWords[:300]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, _ := g.BestGuess()
+	want := g.BestGuesses(2)[1]
+
+	// A hint which is entirely unknown rules nothing out, so without remembering it, first would still be the best.
+	if _, _, err := g.Apply(first, "?????"); err != nil {
+		t.Fatal(err)
+	}
+
+	if guess, entropy := g.BestGuess(); guess != want.Word || entropy != want.Score {
+		t.Errorf("best guess after guessing %v is %v (%v), want the next best %v (%v)", first, guess, entropy, want.Word,
+			want.Score)
+	}
+}
+
+func TestEntropyReference(t *testing.T) {
+	defer quiet()()
+
+	dictionary := ValidWords[:1000]
+	hint := createHint("tares", "moist").String()
+
+	var remaining []string
+	scores := map[EntropyReference]float64{}
+	for _, reference := range []EntropyReference{CurrentRemaining, OriginalFull} {
+		g, err := NewGame(GameOptions{Dictionary: dictionary, EntropyReference: reference})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := g.Apply("tares", hint); err != nil {
+			t.Fatal(err)
+		}
+
+		scores[reference] = g.ScoreGuess("colon")
+		remaining = g.dictionary
+	}
+
+	if want := calculateEntropySerially("colon", remaining); math.Abs(scores[CurrentRemaining]-want) > 1e-12 {
+		t.Errorf("entropy against the remaining words is %v, want %v", scores[CurrentRemaining], want)
+	}
+
+	if want := calculateEntropySerially("colon", dictionary); math.Abs(scores[OriginalFull]-want) > 1e-12 {
+		t.Errorf("entropy against the original dictionary is %v, want %v", scores[OriginalFull], want)
+	}
+
+	if scores[CurrentRemaining] == scores[OriginalFull] {
+		t.Errorf("entropy is %v against both dictionaries", scores[CurrentRemaining])
+	}
+}
+
+func TestAnswersOnly(t *testing.T) {
+	defer quiet()()
+
+	options := GameOptions{Dictionary: []string{"bills", "fills", "hills", "mills"}, Guesses: []string{"fhmbz"}}
+
+	g, err := NewGame(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The probe tells every possible answer apart, which no possible answer can.
+	if guess, _ := g.BestGuess(); guess != "fhmbz" {
+		t.Fatalf("best guess is %v, want the probe fhmbz", guess)
+	}
+
+	options.AnswersOnly = true
+	for _, answer := range options.Dictionary {
+		options.Answer = answer
+
+		result, err := Solve(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, turn := range result.Turns {
+			if turn.Guess == "fhmbz" {
+				t.Errorf("%v: guessed the guess-only word %v with AnswersOnly", answer, turn.Guess)
+			}
+		}
+	}
+
+	options.FirstGuess = "fhmbz"
+	if _, err := NewGame(options); err == nil {
+		t.Error("a guess-only first guess with AnswersOnly didn't return an error")
+	}
+}
+
+func TestGuessOutcomeOdds(t *testing.T) {
+	dictionary := []string{"bills", "fills", "hills", "moist"}
+
+	tests := []struct {
+		weights               map[string]float64
+		word                  string
+		winNow, singletonNext float64
+	}{
+		// fills and hills give the same hint, but moist doesn't.
+		{nil, "bills", 0.25, 0.25},
+		{nil, "moist", 0.25, 0},
+		// Doesn't guess any of the possible answers, but tells every one apart.
+		{nil, "fhmbz", 0, 1},
+		{map[string]float64{"bills": 5, "fills": 1, "hills": 1, "moist": 1}, "bills", 5.0 / 8, 1.0 / 8},
+	}
+
+	for _, test := range tests {
+		g, err := NewGame(GameOptions{Dictionary: dictionary, Weights: test.weights})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		winNow, singletonNext := g.GuessOutcomeOdds(test.word)
+		if math.Abs(winNow-test.winNow) > 1e-12 || math.Abs(singletonNext-test.singletonNext) > 1e-12 {
+			t.Errorf("GuessOutcomeOdds(%v) with weights %v = %v, %v, want %v, %v", test.word, test.weights, winNow,
+				singletonNext, test.winNow, test.singletonNext)
+		}
+	}
+}
+
+func TestTopK(t *testing.T) {
+	scores := []ScoredGuess{{"bills", 1}, {"fills", 3}, {"hills", 2}, {"moist", 3}, {"crane", 0}}
+
+	tests := []struct {
+		k    int
+		want string
+	}{
+		{0, "[{bills 1} {fills 3} {hills 2} {moist 3} {crane 0}]"},
+		{5, "[{bills 1} {fills 3} {hills 2} {moist 3} {crane 0}]"},
+		{2, "[{fills 3} {moist 3}]"},
+		// Keeps the original order, and the first of tied guesses.
+		{3, "[{fills 3} {hills 2} {moist 3}]"},
+		{1, "[{fills 3}]"},
+	}
+
+	for _, test := range tests {
+		if got := fmt.Sprint(topK(scores, test.k)); got != test.want {
+			t.Errorf("topK(%v) = %v, want %v", test.k, got, test.want)
+		}
+	}
+}
+
+func TestCandidateTopKAtLeastCandidates(t *testing.T) {
+	defer quiet()()
+
+	dictionary := ValidWords[:300]
+
+	for _, options := range []GameOptions{
+		{Dictionary: dictionary, Lambda: func(int) float64 { return 0.5 }},
+		{Dictionary: dictionary, GuessBudget: 4},
+	} {
+		for _, answer := range dictionary[:15] {
+			options := options
+			options.Answer = answer
+
+			want, err := Solve(options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, k := range []int{len(dictionary), len(dictionary) + 100} {
+				options.CandidateTopK = k
+
+				got, err := Solve(options)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if fmt.Sprint(got.Turns) != fmt.Sprint(want.Turns) {
+					t.Errorf("%v: with CandidateTopK %v guessed %v, without %v", answer, k, got.Turns, want.Turns)
+				}
+			}
+		}
+	}
+}
+
+// benchmarkChooseGuess benchmarks choosing between the guesses left after guessing tares, with a GuessBudget which
+// needs the information each guess is guaranteed to reveal, and CandidateTopK k.
+func benchmarkChooseGuess(b *testing.B, k int) {
+	defer quiet()()
+
+	g, err := NewGame(GameOptions{GuessBudget: 6, CandidateTopK: k})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	if _, _, err := g.Apply("tares", "bbbbb"); err != nil {
+		b.Fatal(err)
+	}
+
+	scores := g.score(g.allowed)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		g.chooseGuess(scores)
+	}
+}
+
+func BenchmarkChooseGuessAllCandidates(b *testing.B) {
+	benchmarkChooseGuess(b, 0)
+}
+
+func BenchmarkChooseGuessTop10(b *testing.B) {
+	benchmarkChooseGuess(b, 10)
+}
+
+func TestAnswer(t *testing.T) {
+	g, err := NewGame(GameOptions{Dictionary: ValidWords[:100], Answer: ValidWords[42]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if answer, ok := g.Answer(); !ok || answer != ValidWords[42] {
+		t.Errorf("Answer() = %v, %v, want %v, true", answer, ok, ValidWords[42])
+	}
+
+	// Clones know it too.
+	if answer, ok := g.Clone().Answer(); !ok || answer != ValidWords[42] {
+		t.Errorf("clone's Answer() = %v, %v, want %v, true", answer, ok, ValidWords[42])
+	}
+
+	g, err = NewGame(GameOptions{Dictionary: ValidWords[:100]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if answer, ok := g.Answer(); ok || answer != "" {
+		t.Errorf("Answer() of a game played by a person = %v, %v, want it unknown", answer, ok)
+	}
+}
+
+func TestGap(t *testing.T) {
+	defer quiet()()
+
+	dictionary := ValidWords[:300]
+
+	result, err := Solve(GameOptions{Dictionary: dictionary, Answer: "moist"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Replay the game, scoring every candidate from scratch before each guess after the first.
+	g, err := NewGame(GameOptions{Dictionary: dictionary})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checked := 0
+	for i, turn := range result.Turns {
+		if i != 0 {
+			scores := g.score(append(append([]string(nil), g.dictionary...), g.candidateGuessOnly()...))
+			sort.SliceStable(scores, func(i, j int) bool {
+				return scores[i].Score > scores[j].Score
+			})
+
+			want := 0.0
+			if len(scores) >= 2 {
+				want = scores[0].Score - scores[1].Score
+				checked++
+			}
+
+			if math.Abs(turn.Gap-want) > 1e-12 {
+				t.Errorf("gap of guess %v is %v, want %v", i+1, turn.Gap, want)
+			}
+		}
+
+		if _, _, err := g.Apply(turn.Guess, turn.Hint); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if checked == 0 {
+		t.Fatalf("no guesses had a runner-up: %v", result.Turns)
+	}
+
+	Verbose = true
+	g, err = NewGame(GameOptions{Dictionary: dictionary, Answer: "moist", ShowGap: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := captureOutput(t, func() {
+		g.Play()
+	})
+
+	if want := fmt.Sprintf("(Guess #2) Gap:        %v ahead of the runner-up\n", result.Turns[1].Gap); !strings.Contains(output, want) {
+		t.Errorf("output doesn't contain %q:\n%v", want, output)
+	}
+}
+
+func TestExcludedAt(t *testing.T) {
+	g, err := NewGame(GameOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// t and s are yellow, so not where they were guessed, and a, r and e aren't anywhere.
+	if _, _, err := g.Apply("tares", createHint("tares", "moist").String()); err != nil {
+		t.Fatal(err)
+	}
+
+	for pos, want := range []string{"aert", "aer", "aer", "aer", "aers"} {
+		if got := string(g.ExcludedAt(pos)); got != want {
+			t.Errorf("ExcludedAt(%v) after tares = %q, want %q", pos, got, want)
+		}
+	}
+
+	// o, i and t are green, so every other letter is ruled out there.
+	if _, _, err := g.Apply("joint", createHint("joint", "moist").String()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := string(g.ExcludedAt(0)), "aejnrt"; got != want {
+		t.Errorf("ExcludedAt(0) after joint = %q, want %q", got, want)
+	}
+
+	if got, want := string(g.ExcludedAt(4)), "abcdefghijklmnopqrsuvwxyz"; got != want {
+		t.Errorf("ExcludedAt(4) after joint = %q, want %q", got, want)
+	}
+}
+
+func TestMeanCandidateEntropy(t *testing.T) {
+	defer quiet()()
+
+	g, err := NewGame(GameOptions{Dictionary: []string{"bills", "fills", "hills", "moist"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each _ills word tells itself and moist apart from the other two. moist only tells itself apart.
+	ills := 0.25*2 + 0.5*1 + 0.25*2
+	moist := 0.25*2 + 0.75*math.Log2(4.0/3)
+	if got, want := g.MeanCandidateEntropy(), (3*ills+moist)/4; math.Abs(got-want) > 1e-12 {
+		t.Errorf("MeanCandidateEntropy() = %v, want %v", got, want)
+	}
+
+	Verbose = true
+	g, err = NewGame(GameOptions{Dictionary: ValidWords[:300], Answer: "moist"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := captureOutput(t, func() {
+		g.Play()
+	})
+
+	if !strings.Contains(output, "(Guess #1) Mean entropy: ") {
+		t.Errorf("output doesn't contain the mean entropy:\n%v", output)
+	}
+}
+
+func TestBestGuessWithLetter(t *testing.T) {
+	defer quiet()()
+
+	g, err := NewGame(GameOptions{Dictionary: ValidWords[:300]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, letter := range []byte("rzq") {
+		guess, entropy := g.BestGuessWithLetter(letter)
+
+		var want ScoredGuess
+		for _, word := range g.dictionary {
+			if strings.IndexByte(word, letter) == -1 {
+				continue
+			}
+
+			if score := g.ScoreGuess(word); want.Word == "" || score > want.Score {
+				want = ScoredGuess{Word: word, Score: score}
+			}
+		}
+
+		if guess != want.Word || entropy != want.Score {
+			t.Errorf("best guess with %c is %v (%v), want %v (%v)", letter, guess, entropy, want.Word, want.Score)
+		}
+	}
+}
+
+func TestBestGuessWithLetterBadLetter(t *testing.T) {
+	g, err := NewGame(GameOptions{Dictionary: ValidWords[:300]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("best guess with R didn't panic")
+		}
+	}()
+
+	g.BestGuessWithLetter('R')
+}
+
+func TestMaxCoverageGuess(t *testing.T) {
+	g, err := NewGame(GameOptions{Dictionary: []string{"eerie", "sissy", "crane", "tares", "moist"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// crane, tares and moist all test 5 letters, but those of tares are in the most possible answers.
+	if guess, letters := g.MaxCoverageGuess(nil); guess != "tares" || letters != 5 {
+		t.Errorf("MaxCoverageGuess() = %v, %v, want tares, 5", guess, letters)
+	}
+
+	if guess, letters := g.MaxCoverageGuess(map[byte]bool{'t': true, 'a': true, 'r': true, 'e': true, 's': true}); guess != "moist" || letters != 3 {
+		t.Errorf("MaxCoverageGuess() after tares = %v, %v, want moist, 3", guess, letters)
+	}
+
+	g, err = NewGame(GameOptions{Dictionary: ValidWords[:300]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exclude := map[byte]bool{'e': true, 'a': true, 'r': true, 'o': true, 't': true}
+	guess, letters := g.MaxCoverageGuess(exclude)
+
+	most := 0
+	for _, word := range g.dictionary {
+		seen := map[byte]bool{}
+		for i := 0; i < wordSize; i++ {
+			if !exclude[word[i]] {
+				seen[word[i]] = true
+			}
+		}
+
+		if len(seen) > most {
+			most = len(seen)
+		}
+	}
+
+	if letters != most {
+		t.Errorf("MaxCoverageGuess() = %v, testing %v new letters, want %v", guess, letters, most)
+	}
+}
+
+func TestGuessLetterStats(t *testing.T) {
+	weights := map[string]float64{"bills": 5, "fills": 1, "hills": 1, "moist": 1}
+
+	for _, w := range []map[string]float64{nil, weights} {
+		g, err := NewGame(GameOptions{Dictionary: ValidWords[:300], Weights: w})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, word := range []string{"tares", "moist", "fhmbz"} {
+			for i, probabilities := range g.GuessLetterStats(word) {
+				if sum := probabilities[Absent] + probabilities[Present] + probabilities[Correct]; math.Abs(sum-1) > 1e-9 {
+					t.Errorf("GuessLetterStats(%v) with weights %v at position %v = %v, which sums to %v, want 1", word, w,
+						i, probabilities, sum)
+				}
+			}
+		}
+	}
+
+	g, err := NewGame(GameOptions{Dictionary: []string{"bills", "fills", "hills", "moist"}, Weights: weights})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only moist lacks the l in the 4th position.
+	if stats := g.GuessLetterStats("bills"); math.Abs(stats[3][Absent]-1.0/8) > 1e-12 || math.Abs(stats[3][Correct]-7.0/8) > 1e-12 {
+		t.Errorf("GuessLetterStats(bills)[3] = %v, want 1/8 absent and 7/8 correct", stats[3])
+	}
+}
+
+func TestIndistinguishable(t *testing.T) {
+	dictionary := []string{"bills", "fills", "hills", "jills", "moist"}
+	blocked := map[string]bool{"fills": true, "hills": true, "jills": true}
+
+	tests := []struct {
+		guesses []string
+		blocked map[string]bool
+		want    string
+	}{
+		// Any of the twins can be guessed, which tells it apart from the rest.
+		{nil, nil, "[]"},
+		// Neither bills nor moist tell the words differing only in their first letter apart.
+		{nil, blocked, "[[fills hills jills]]"},
+		// fhmbz has two of their first letters, so it tells every one apart.
+		{[]string{"fhmbz"}, blocked, "[]"},
+		// fagot only has one of them, so the other two are still twins.
+		{[]string{"fagot"}, blocked, "[[hills jills]]"},
+	}
+
+	for _, test := range tests {
+		g, err := NewGame(GameOptions{Dictionary: dictionary, Guesses: test.guesses, BlockedGuesses: test.blocked})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := fmt.Sprint(g.Indistinguishable()); got != test.want {
+			t.Errorf("Indistinguishable() with guesses %v and blocked guesses %v = %v, want %v", test.guesses,
+				test.blocked, got, test.want)
+		}
+	}
+
+	// Only the twins are left, once moist is ruled out.
+	g, err := NewGame(GameOptions{Dictionary: dictionary, BlockedGuesses: blocked})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := g.Apply("moist", createHint("moist", "fills").String()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fmt.Sprint(g.Indistinguishable()); got != "[[fills hills jills]]" {
+		t.Errorf("Indistinguishable() after moist = %v, want [[fills hills jills]]", got)
+	}
+
+	if _, _, err := g.Apply("bills", createHint("bills", "fills").String()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fmt.Sprint(g.Indistinguishable()); got != "[[fills hills jills]]" {
+		t.Errorf("Indistinguishable() after moist and bills = %v, want [[fills hills jills]]", got)
+	}
+}
+
+func TestNewGameFromWordList(t *testing.T) {
+	words := []string{"Bills", " fills ", "hills", "moist", "bills", "cat", "elephant", "fhmbz", "z3zzz"}
+
+	tests := []struct {
+		weights             map[string]float64
+		dictionary, allowed string
+	}{
+		{nil, "[bills fills hills moist fhmbz]", "[bills fills hills moist fhmbz]"},
+		// Words which aren't likely to be the answer can still be guessed.
+		{map[string]float64{"bills": 2, "fills": 1, "hills": 1, "moist": 0.5, "fhmbz": 0}, "[bills fills hills moist]", "[bills fills hills moist fhmbz]"},
+	}
+
+	for _, test := range tests {
+		g, err := NewGameFromWordList(words, test.weights)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if dictionary, allowed := fmt.Sprint(g.dictionary), fmt.Sprint(g.allowed); dictionary != test.dictionary || allowed != test.allowed {
+			t.Errorf("NewGameFromWordList() with weights %v has possible answers %v and guesses %v, want %v and %v",
+				test.weights, dictionary, allowed, test.dictionary, test.allowed)
+		}
+
+		if weighted := test.weights != nil; weighted != (g.options.TiePolicy == TieWeight) {
+			t.Errorf("NewGameFromWordList() with weights %v breaks ties with policy %v", test.weights, g.options.TiePolicy)
+		}
+
+		// Every possible answer can be solved from the word list.
+		for _, answer := range g.dictionary {
+			solving := g.Clone()
+			solving.options.Answer = answer
+
+			if result := solving.solve(); result.Answer != answer || result.Guesses > 3 {
+				t.Errorf("solving %v from the word list with weights %v found %v in %v guesses", answer, test.weights,
+					result.Answer, result.Guesses)
+			}
+		}
+	}
+
+	if _, err := NewGameFromWordList(words, map[string]float64{"cat": 1, "bills": 0}); err == nil {
+		t.Error("NewGameFromWordList() without any possible answers didn't return an error")
+	}
+}
+
+func TestOnAnswer(t *testing.T) {
+	defer quiet()()
+
+	var answers []string
+	onAnswer := func(word string) {
+		answers = append(answers, word)
+	}
+
+	dictionary := ValidWords[:300]
+
+	if _, err := Solve(GameOptions{Dictionary: dictionary, Answer: "moist", OnAnswer: onAnswer}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Played by the computer.
+	g, err := NewGame(GameOptions{Dictionary: dictionary, Answer: "chill", OnAnswer: onAnswer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Play()
+
+	// Played by a person, who enters the answer once they know it.
+	playInput(t, GameOptions{Dictionary: dictionary, OnAnswer: onAnswer},
+		"crane\n"+createHint("crane", "basic").String()+"\n!basic\n")
+
+	if got := fmt.Sprint(answers); got != "[moist chill basic]" {
+		t.Errorf("OnAnswer was called with %v, want moist, chill and basic", got)
+	}
+}
+
+func TestPositionEntropy(t *testing.T) {
+	defer quiet()()
+
+	dictionary := []string{"bills", "fills", "hills", "jills"}
+
+	tests := []struct {
+		weights map[string]float64
+		first   float64
+	}{
+		{nil, 2},
+		{map[string]float64{"bills": 2, "fills": 1, "hills": 1}, 1.5},
+	}
+
+	for _, test := range tests {
+		g, err := NewGame(GameOptions{Dictionary: dictionary, Weights: test.weights})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Every word ends in ills, so only the first letter is still uncertain.
+		want := [wordSize]float64{test.first}
+		got := g.PositionEntropy()
+		for i := range got {
+			if math.Abs(got[i]-want[i]) > 1e-12 {
+				t.Errorf("PositionEntropy() with weights %v = %v, want %v", test.weights, got, want)
+				break
+			}
+		}
+	}
+
+	g, err := NewGame(GameOptions{Dictionary: ValidWords[:300]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := g.Apply("moist", "ggggg"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := g.PositionEntropy(); got != [wordSize]float64{} {
+		t.Errorf("PositionEntropy() once solved = %v, want 0 for every position", got)
+	}
+
+	if got := positionBar([wordSize]float64{0, math.Log2(26), 0, math.Log2(26) / 2, 0}); got != "▁█▁▅▁" {
+		t.Errorf("positionBar() = %v, want ▁█▁▅▁", got)
+	}
+
+	Verbose = true
+	_, output := playInput(t, GameOptions{Dictionary: append([]string{"moist"}, dictionary...)},
+		"moist\n"+createHint("moist", "bills").String()+"\n\nggggg\n")
+
+	if !strings.Contains(output, "Positions:  ▄▁▁▁▁") {
+		t.Errorf("the uncertainty of each position wasn't shown:\n%v", output)
+	}
+}
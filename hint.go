@@ -2,51 +2,240 @@ package wordle
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 )
 
 // A wordHint is a hint for an entire word.
-type wordHint [wordSize]letterHint
+type wordHint [wordSize]LetterHint
 
-// fromString parses this word hint from s, returning an error if s is invalid.
+// fromString parses this word hint from s, returning an error if s is invalid. The error is a *WrongLengthError or an
+// *InvalidHintCharError.
 func (w *wordHint) fromString(s string) error {
 	if len(s) != wordSize {
-		return fmt.Errorf("wrong size: expected %v, got %v", wordSize, len(s))
+		return &WrongLengthError{Expected: wordSize, Got: len(s)}
 	}
 
 	for i := 0; i < len(s); i++ {
-		switch s[i] {
-		case 'b':
-			w[i] = absent
-		case 'g':
-			w[i] = correct
-		case 'y':
-			w[i] = present
-		default:
-			return fmt.Errorf("unexpected hint %v, use absent = b (black), present = y (yellow), correct = g (green)", string(s[i]))
+		h, ok := parseLetterHint(s[i])
+		if !ok {
+			return &InvalidHintCharError{Position: i, Char: s[i]}
 		}
+
+		w[i] = h
 	}
 
 	return nil
 }
 
-// A letterHint is a hint for a single letter. A letter is either absent from the word, present in the word but somewhere else,
+func (w wordHint) String() string {
+	var result strings.Builder
+
+	for _, h := range w {
+		result.WriteString(h.String())
+	}
+
+	return result.String()
+}
+
+// index returns the position of w in possibleWordHints: w read as a number in base 3, with the hint of the first letter
+// as the least significant digit. w can't have unknown letters.
+func (w wordHint) index() int {
+	index := 0
+	for i := wordSize - 1; i >= 0; i-- {
+		index = index*3 + int(w[i])
+	}
+
+	return index
+}
+
+// solved returns whether every letter in w is correct - i.e. whether the guess was the answer.
+func (w wordHint) solved() bool {
+	for _, h := range w {
+		if h != Correct {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matches returns whether the hint actual, created by createHint, matches w. Every letter hint must be the same, except
+// where w's letter hint is unknown - any letter hint matches that.
+func (w wordHint) matches(actual wordHint) bool {
+	for i, h := range w {
+		if h != unknown && h != actual[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// mask returns which positions of w are h.
+func (w wordHint) mask(h LetterHint) [wordSize]bool {
+	var result [wordSize]bool
+
+	for i := range w {
+		result[i] = w[i] == h
+	}
+
+	return result
+}
+
+// A LetterHint is a hint for a single letter. A letter is either absent from the word, present in the word but somewhere else,
 // or correct and in the right position.
-type letterHint int
+//
+// A hint can also be unknown, e.g. if it wasn't read correctly. This means it could be any of the others. createHint
+// never creates unknown hints, so they aren't included in LetterHints.
+type LetterHint int
 
+// The hints a letter can get.
 const (
-	absent letterHint = iota
-	present
-	correct
+	Absent LetterHint = iota
+	Present
+	Correct
+	unknown
 )
 
-func (h letterHint) String() string {
+// LetterHints returns the hints a letter can get: Absent, Present and Correct.
+func LetterHints() []LetterHint {
+	return []LetterHint{Absent, Present, Correct}
+}
+
+// ParseLetterHint parses a letter hint from the format String returns: b (black) for Absent, y (yellow) for Present,
+// g (green) for Correct or ? for an unknown hint. The error is a *WrongLengthError or an *InvalidHintCharError.
+func ParseLetterHint(s string) (LetterHint, error) {
+	if len(s) != 1 {
+		return 0, &WrongLengthError{Expected: 1, Got: len(s)}
+	}
+
+	h, ok := parseLetterHint(s[0])
+	if !ok {
+		return 0, &InvalidHintCharError{Char: s[0]}
+	}
+
+	return h, nil
+}
+
+// parseLetterHint parses a letter hint from c, returning whether it's valid. Symbols registered with
+// RegisterHintSymbol are valid too.
+func parseLetterHint(c byte) (LetterHint, bool) {
+	switch c {
+	case 'b':
+		return Absent, true
+	case 'y':
+		return Present, true
+	case 'g':
+		return Correct, true
+	case '?':
+		return unknown, true
+	default:
+		return hintSymbols.get(c)
+	}
+}
+
+// RegisterHintSymbol makes c parse as h in typed hints, transcripts and everywhere else hints are read, in addition to
+// the usual b, y, g and ? - e.g. to read hints from a variant which marks absent letters with -. Hints are still shown
+// with the usual symbols.
+//
+// It returns an error if c is whitespace, a letter, already parses as a hint, or if h isn't one of LetterHints. Letters
+// can't be symbols, since then guesses could be mistaken for hints.
+func RegisterHintSymbol(c byte, h LetterHint) error {
+	if c <= ' ' || c > '~' {
+		return fmt.Errorf("bad hint symbol %q: must be a printable ASCII character", c)
+	}
+
+	if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+		return fmt.Errorf("bad hint symbol %q: can't be a letter", c)
+	}
+
+	if h < Absent || h > Correct {
+		return fmt.Errorf("bad hint %v for symbol %q: must be absent, present or correct", int(h), c)
+	}
+
+	if existing, ok := parseLetterHint(c); ok {
+		return fmt.Errorf("hint symbol %q already parses as %v", c, existing)
+	}
+
+	if existing, ok := hintSymbols.add(c, h); !ok {
+		return fmt.Errorf("hint symbol %q already parses as %v", c, existing)
+	}
+
+	return nil
+}
+
+// A hintSymbolTable holds the extra symbols hints can be typed with, and the hint each parses as. It's safe for
+// concurrent use.
+type hintSymbolTable struct {
+	mu      sync.RWMutex
+	symbols map[byte]LetterHint
+}
+
+// get returns the hint c parses as, if it's been registered.
+func (t *hintSymbolTable) get(c byte) (LetterHint, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	h, ok := t.symbols[c]
+	return h, ok
+}
+
+// add registers c as parsing as h. If c has already been registered, it returns false and the hint c parses as
+// without changing anything.
+func (t *hintSymbolTable) add(c byte, h LetterHint) (LetterHint, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if existing, ok := t.symbols[c]; ok {
+		return existing, false
+	}
+
+	t.symbols[c] = h
+	return h, true
+}
+
+// hintSymbols holds the symbols registered with RegisterHintSymbol.
+var hintSymbols = &hintSymbolTable{symbols: map[byte]LetterHint{}}
+
+// String returns h as it's typed in hints: b (black) for Absent, y (yellow) for Present, g (green) for Correct or ? for
+// an unknown hint.
+func (h LetterHint) String() string {
 	switch h {
-	case absent:
+	case Absent:
 		return "b"
-	case present:
+	case Present:
 		return "y"
-	case correct:
+	case Correct:
 		return "g"
+	case unknown:
+		return "?"
+	default:
+		panic(h)
+	}
+}
+
+// emojis returns w as emoji squares, like a row of the grid shared by the official game.
+func (w wordHint) emojis() string {
+	var row strings.Builder
+	for _, h := range w {
+		row.WriteString(h.emoji())
+	}
+
+	return row.String()
+}
+
+// emoji returns the square used to show h when sharing, like the official game.
+func (h LetterHint) emoji() string {
+	switch h {
+	case Absent:
+		return "⬛"
+	case Present:
+		return "🟨"
+	case Correct:
+		return "🟩"
+	case unknown:
+		return "❔"
 	default:
 		panic(h)
 	}
@@ -55,7 +244,7 @@ func (h letterHint) String() string {
 // createHint returns the hint associated with guess if the actual word is answer.
 func createHint(guess, answer string) wordHint {
 	// unscramble maps answer letter positions to the guess letter positions they correspond to
-	unscramble := map[int]int{}
+	var unscramble [wordSize]int
 	for letterIndex := 0; letterIndex < wordSize; letterIndex++ {
 		unscramble[letterIndex] = -1
 	}
@@ -92,9 +281,9 @@ func createHint(guess, answer string) wordHint {
 	for index, mapping := range unscramble {
 		switch {
 		case mapping == index: // the answer letter maps to the same position as the guess letter: the guess is correct
-			hint[mapping] = correct
+			hint[mapping] = Correct
 		case mapping != -1: // the answer letter maps to a different position in the guess: the guess is present
-			hint[mapping] = present
+			hint[mapping] = Present
 
 			// in the default case, the answer letter has no mapping to the guess. The default value for wordHint is absent,
 			// so doing nothing will keep that position absent
@@ -0,0 +1,139 @@
+package wordle
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func BenchmarkCreateHint(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		createHint("tares", "eerie")
+	}
+}
+
+func TestCreateHintForAnswer(t *testing.T) {
+	words := append([]string{"geese", "eerie", "mamma", "fuzzy"}, ValidWords[:500]...)
+
+	for _, word := range words {
+		hint := createHint(word, word)
+		if !hint.solved() {
+			t.Errorf("guessing the answer %v gave %v, want all correct", word, hint)
+		}
+
+		if c := (Constraint{hint: hint, word: word}); !c.Satisfies(word) {
+			t.Errorf("%v doesn't satisfy the constraint from guessing itself", word)
+		}
+	}
+}
+
+func TestLetterHintRoundTrip(t *testing.T) {
+	want := map[LetterHint]string{Absent: "b", Present: "y", Correct: "g"}
+
+	hints := LetterHints()
+	if len(hints) != len(want) {
+		t.Fatalf("LetterHints() = %v, want Absent, Present and Correct", hints)
+	}
+
+	for _, h := range hints {
+		s := h.String()
+		if s != want[h] {
+			t.Errorf("%d.String() = %q, want %q", h, s, want[h])
+		}
+
+		parsed, err := ParseLetterHint(s)
+		if err != nil || parsed != h {
+			t.Errorf("ParseLetterHint(%q) = %v, %v, want %v", s, parsed, err, h)
+		}
+	}
+
+	for _, s := range []string{"x", "", "bb"} {
+		if _, err := ParseLetterHint(s); err == nil {
+			t.Errorf("ParseLetterHint(%q) didn't return an error", s)
+		}
+	}
+}
+
+func TestRegisterHintSymbol(t *testing.T) {
+	defer func() {
+		hintSymbols.mu.Lock()
+		defer hintSymbols.mu.Unlock()
+
+		delete(hintSymbols.symbols, '-')
+		delete(hintSymbols.symbols, '+')
+	}()
+
+	if _, err := ParseLetterHint("-"); err == nil {
+		t.Fatal("ParseLetterHint(\"-\") didn't return an error before - was registered")
+	}
+
+	for c, h := range map[byte]LetterHint{'-': Absent, '+': Correct} {
+		if err := RegisterHintSymbol(c, h); err != nil {
+			t.Fatalf("RegisterHintSymbol(%q, %v) = %v", c, h, err)
+		}
+
+		if parsed, err := ParseLetterHint(string(c)); err != nil || parsed != h {
+			t.Errorf("ParseLetterHint(%q) = %v, %v, want %v", c, parsed, err, h)
+		}
+	}
+
+	// Hints typed with the custom symbols narrow down the possible answers like the usual ones.
+	custom, err := NewGame(GameOptions{Dictionary: ValidWords[:300]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	usual, err := NewGame(GameOptions{Dictionary: ValidWords[:300]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := custom.Apply("tares", "-y+--"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := usual.Apply("tares", "bygbb"); err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(custom.dictionary) != fmt.Sprint(usual.dictionary) {
+		t.Errorf("hint -y+-- left %v, want %v like bygbb", custom.dictionary, usual.dictionary)
+	}
+
+	if hint := custom.turns[0].Hint; hint != "bygbb" {
+		t.Errorf("hint -y+-- is shown as %v, want bygbb", hint)
+	}
+
+	tests := []struct {
+		c byte
+		h LetterHint
+	}{
+		{'-', Present}, // already registered
+		{'b', Present}, // already a hint
+		{' ', Absent},  // whitespace
+		{'*', unknown}, // not one of LetterHints
+		{'x', Absent},  // a letter, so guesses could be read as hints
+		{'X', Absent},
+	}
+
+	for _, test := range tests {
+		if err := RegisterHintSymbol(test.c, test.h); err == nil {
+			t.Errorf("RegisterHintSymbol(%q, %v) didn't return an error", test.c, test.h)
+		}
+	}
+
+	if parsed, err := ParseLetterHint("-"); err != nil || parsed != Absent {
+		t.Errorf("ParseLetterHint(\"-\") after registering it again = %v, %v, want Absent", parsed, err)
+	}
+
+	// The error says what the symbol already parses as.
+	if err := RegisterHintSymbol('+', Present); err == nil || !strings.Contains(err.Error(), "already parses as g") {
+		t.Errorf("RegisterHintSymbol('+', Present) after registering it as Correct = %v, want it to say it's g", err)
+	}
+
+	if existing, ok := hintSymbols.add('+', Present); ok || existing != Correct {
+		t.Errorf("adding + again = %v, %v, want Correct, false", existing, ok)
+	}
+}
@@ -0,0 +1,171 @@
+package wordle
+
+// The number of letters in the alphabet words are made of.
+const alphabetSize = 26
+
+// A letterSet is a set of letters, where bit i is set if the letter 'a'+i is in the set.
+type letterSet uint32
+
+// allLetters is the letterSet containing every letter.
+const allLetters letterSet = 1<<alphabetSize - 1
+
+// contains returns whether letter is in s.
+func (s letterSet) contains(letter byte) bool {
+	return s&(1<<(letter-'a')) != 0
+}
+
+// knowledge is everything known about the answer from the hints revealed so far: which letters can be at each position,
+// and how many times each letter can be in the answer.
+//
+// Checking whether a word is allowed by knowledge is equivalent to checking whether it satisfies the constraint of each
+// hint folded into it, but only needs to look at the word once. This holds for every hint createHint can produce -
+// other hints (e.g. an absent letter before a present copy of the same letter) can't be satisfied by any word, but
+// knowledge doesn't detect this. Hints with unknown letters are folded in conservatively, so knowledge may allow words
+// the constraint doesn't.
+type knowledge struct {
+	allowed [wordSize]letterSet
+
+	// The bounds on how many times each letter is in the answer, indexed by letter - 'a'.
+	minCount, maxCount [alphabetSize]int
+}
+
+// newKnowledge returns the knowledge known before any hints are revealed: any letter could be anywhere.
+func newKnowledge() knowledge {
+	var k knowledge
+
+	for i := range k.allowed {
+		k.allowed[i] = allLetters
+	}
+
+	for i := range k.maxCount {
+		k.maxCount[i] = wordSize
+	}
+
+	return k
+}
+
+// add folds the hint guess resulted in into k.
+func (k *knowledge) add(guess string, hint wordHint) {
+	// The number of times each letter is known to be in the answer from this hint, and whether that's the exact number.
+	// Unknown letters might be in the answer, so they make the count more uncertain.
+	var counts, unknowns [alphabetSize]int
+	var exact [alphabetSize]bool
+
+	for i := 0; i < wordSize; i++ {
+		letter := guess[i] - 'a'
+
+		switch hint[i] {
+		case Correct:
+			k.allowed[i] = 1 << letter
+			counts[letter]++
+		case Present:
+			k.allowed[i] &^= 1 << letter
+			counts[letter]++
+		case Absent:
+			// The letter isn't here. It also isn't anywhere else, aside from the copies of it which were correct or
+			// present.
+			k.allowed[i] &^= 1 << letter
+			exact[letter] = true
+		case unknown:
+			unknowns[letter]++
+		}
+	}
+
+	for letter := range counts {
+		if counts[letter] > k.minCount[letter] {
+			k.minCount[letter] = counts[letter]
+		}
+
+		if exact[letter] && counts[letter]+unknowns[letter] < k.maxCount[letter] {
+			k.maxCount[letter] = counts[letter] + unknowns[letter]
+		}
+	}
+}
+
+// foldsExactly returns whether adding the hint guess resulted in to knowledge loses nothing, i.e. whether allows is then
+// equivalent to also checking the hint's constraint. This is the case for every hint createHint can produce: ones
+// without unknown letters, where no copy of a letter is absent before a present copy of it.
+func foldsExactly(guess string, hint wordHint) bool {
+	var absent [alphabetSize]bool
+
+	for i := 0; i < wordSize; i++ {
+		letter := guess[i] - 'a'
+
+		switch hint[i] {
+		case Present:
+			if absent[letter] {
+				return false
+			}
+		case Absent:
+			absent[letter] = true
+		case unknown:
+			return false
+		}
+	}
+
+	return true
+}
+
+// Satisfies returns whether word could be the answer given k. It lets knowledge be used as a wordFilter.
+func (k knowledge) Satisfies(word string) bool {
+	return len(word) == wordSize && k.allows(word)
+}
+
+// addRevealed folds the letters the hint guess resulted in reveals into k: correct letters are where they are, and
+// present letters are somewhere. Unlike add, nothing is learned from absent letters or where present letters aren't.
+// These are the rules hard mode requires guesses to follow.
+func (k *knowledge) addRevealed(guess string, hint wordHint) {
+	var counts [alphabetSize]int
+
+	for i := 0; i < wordSize; i++ {
+		letter := guess[i] - 'a'
+
+		switch hint[i] {
+		case Correct:
+			k.allowed[i] = 1 << letter
+			counts[letter]++
+		case Present:
+			counts[letter]++
+		}
+	}
+
+	for letter, count := range counts {
+		if count > k.minCount[letter] {
+			k.minCount[letter] = count
+		}
+	}
+}
+
+// addCount folds c into k.
+func (k *knowledge) addCount(c CountConstraint) {
+	letter := c.Letter - 'a'
+
+	if c.Min > k.minCount[letter] {
+		k.minCount[letter] = c.Min
+	}
+
+	if c.Max < k.maxCount[letter] {
+		k.maxCount[letter] = c.Max
+	}
+}
+
+// allows returns whether word could be the answer given k.
+func (k knowledge) allows(word string) bool {
+	var counts [alphabetSize]int
+
+	for i := 0; i < wordSize; i++ {
+		if !k.allowed[i].contains(word[i]) {
+			return false
+		}
+
+		counts[word[i]-'a']++
+	}
+
+	for letter, count := range counts {
+		if count < k.minCount[letter] || count > k.maxCount[letter] {
+			return false
+		}
+	}
+
+	return true
+}
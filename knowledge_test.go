@@ -0,0 +1,97 @@
+package wordle
+
+import "testing"
+
+func TestKnowledgeAllowsMatchesConstraints(t *testing.T) {
+	answers := []string{"crane", "eerie", "llama", "sassy", "tares"}
+	guesses := []string{"soare", "eerie", "lolly", "sissy", "tweet", "alarm"}
+
+	for _, answer := range answers {
+		k := newKnowledge()
+		var constraints []Constraint
+
+		for _, guess := range guesses {
+			hint := createHint(guess, answer)
+			if !foldsExactly(guess, hint) {
+				t.Fatalf("hint %v for %v isn't folded exactly", hint, guess)
+			}
+
+			k.add(guess, hint)
+			constraints = append(constraints, Constraint{hint: hint, word: guess})
+
+			for _, word := range ValidWords {
+				want := true
+				for _, c := range constraints {
+					if !c.Satisfies(word) {
+						want = false
+						break
+					}
+				}
+
+				if got := k.allows(word); got != want {
+					t.Fatalf("answer %v, after %v guesses: allows(%v) = %v, replaying constraints = %v", answer,
+						len(constraints), word, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestFoldsExactly(t *testing.T) {
+	tests := []struct {
+		guess, hint string
+		want        bool
+	}{
+		{"tares", "bygbb", true},
+		{"eerie", "ybbbb", true},
+		{"eerie", "bybbb", false},
+		{"eerie", "bbbb?", false},
+	}
+
+	for _, test := range tests {
+		var hint wordHint
+		if err := hint.fromString(test.hint); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := foldsExactly(test.guess, hint); got != test.want {
+			t.Errorf("foldsExactly(%v, %v) = %v, want %v", test.guess, test.hint, got, test.want)
+		}
+	}
+}
+
+func TestRecoverMatchesReplayingConstraints(t *testing.T) {
+	g, err := NewGame(GameOptions{Dictionary: ValidWords[:2000]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The second hint is wrong: it's the hint for another answer, and with the others leaves no possible answers.
+	answer := g.dictionary[1234]
+	g.apply("soare", createHint("soare", answer))
+	g.apply("unlit", createHint("unlit", "bonus"))
+	g.apply("unlit", createHint("unlit", answer))
+
+	if len(g.dictionary) != 0 {
+		t.Fatalf("wrong hint left %v possible answers", len(g.dictionary))
+	}
+
+	if _, ok := g.recover(); !ok {
+		t.Fatal("couldn't recover")
+	}
+
+	want := g.startDictionary
+	for _, guess := range []string{"soare", "unlit"} {
+		want = Constraint{hint: createHint(guess, answer), word: guess}.Filter(want)
+	}
+
+	if len(g.dictionary) != len(want) {
+		t.Fatalf("recovered to %v, want %v", g.dictionary, want)
+	}
+
+	for i := range want {
+		if g.dictionary[i] != want[i] {
+			t.Fatalf("recovered to %v, want %v", g.dictionary, want)
+		}
+	}
+}
@@ -1,8 +1,13 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
 	"github.com/danvolchek/wordle"
 	"math/rand"
+	"os"
+	"strings"
 	"time"
 )
 
@@ -12,12 +17,201 @@ const (
 	unknownWord = true
 )
 
+var date = flag.String("date", "", "play the official Wordle from this date (e.g. 2022-01-02), with a known answer")
+
+var transcript = flag.String("transcript", "", "replay the guesses and hints in this file (one \"guess hint\" per line), showing what the solver would have guessed")
+
+var quordle = flag.String("quordle", "", "replay the guesses and hints of a Quordle in this file (one \"guess hint hint hint hint\" per line, \"-\" for solved boards), showing what the solver would guess next")
+
+var resume = flag.String("resume", "", "save the guesses and hints to this file after each turn, first resuming the game saved in it if there is one")
+
+// The number of boards in a Quordle.
+const quordleBoards = 4
+
 func main() {
-	if unknownWord {
-		wordle.NewGame(wordle.GameOptions{}).Play()
-	} else {
-		rand.Seed(time.Now().Unix())
-		randomWord := rand.Intn(2315)
-		wordle.NewGame(wordle.GameOptions{Answer: wordle.ValidWords[randomWord]}).Play()
+	flag.Parse()
+
+	if *quordle != "" {
+		if err := replayQuordle(*quordle); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *transcript != "" {
+		if err := replay(*transcript); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	var options wordle.GameOptions
+
+	switch {
+	case *date != "":
+		t, err := time.Parse("2006-01-02", *date)
+		if err != nil {
+			fmt.Println("Bad date:", err)
+			os.Exit(1)
+		}
+
+		options.Answer, err = wordle.AnswerForDate(t, wordle.Answers)
+		if err != nil {
+			fmt.Println("Bad date:", err)
+			os.Exit(1)
+		}
+	case !unknownWord:
+		options.Answer = wordle.RandomAnswer(rand.New(rand.NewSource(time.Now().UnixNano())))
+	}
+
+	var turns []wordle.Turn
+	if *resume != "" {
+		var err error
+		turns, err = loadTurns(*resume)
+		if err != nil {
+			fmt.Println("Can't resume:", err)
+			os.Exit(1)
+		}
+
+		options.OnTurn = func(turn wordle.Turn) {
+			turns = append(turns, turn)
+			if err := saveTurns(*resume, turns); err != nil {
+				fmt.Println("Can't save:", err)
+			}
+		}
+	}
+
+	game, err := wordle.NewGame(options)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := game.Replay(turns); err != nil {
+		fmt.Printf("Can't resume: %v: %v\n", *resume, err)
+		os.Exit(1)
+	}
+
+	game.Play()
+}
+
+// loadTurns returns the turns saved at path by saveTurns, or none if nothing has been saved there yet.
+func loadTurns(path string) ([]wordle.Turn, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	turns, err := wordle.ParseTranscript(file)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+
+	return turns, nil
+}
+
+// saveTurns saves turns to path as a transcript, replacing whatever was saved there before.
+func saveTurns(path string, turns []wordle.Turn) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := wordle.WriteTranscript(file, turns); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// replay replays the transcript at path, printing the solver's best guess before each recorded guess and the number of
+// possible answers left after it.
+func replay(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	turns, err := wordle.ParseTranscript(file)
+	if err != nil {
+		return fmt.Errorf("%v: %w", path, err)
+	}
+
+	game, err := wordle.NewGame(wordle.GameOptions{})
+	if err != nil {
+		return err
+	}
+
+	wordle.Verbose = false
+
+	for i, turn := range turns {
+		bestGuess, bestEntropy := game.BestGuess()
+		fmt.Printf("(Guess #%v) Best guess: %v (expected entropy: %v)\n", i+1, bestGuess, bestEntropy)
+		fmt.Printf("(Guess #%v) Guess:      %v\n", i+1, turn.Guess)
+		fmt.Printf("(Guess #%v) Hint:       %v\n", i+1, turn.Hint)
+
+		info, remaining, err := game.Apply(turn.Guess, turn.Hint)
+		if err != nil {
+			return fmt.Errorf("guess #%v: %w", i+1, err)
+		}
+
+		fmt.Printf("(Guess #%v) Remaining:  %v (actual entropy: %v)\n", i+1, remaining, info)
+		fmt.Println()
 	}
+
+	return nil
+}
+
+// replayQuordle replays the Quordle transcript at path, printing the solver's best guess before each recorded guess and
+// the number of possible answers left on each board after it. Then, it prints the best next guess.
+func replayQuordle(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	turns, err := wordle.ParseMultiTranscript(file, quordleBoards)
+	if err != nil {
+		return fmt.Errorf("%v: %w", path, err)
+	}
+
+	game, err := wordle.NewMultiGame(wordle.GameOptions{}, quordleBoards)
+	if err != nil {
+		return err
+	}
+
+	wordle.Verbose = false
+
+	for i, turn := range turns {
+		bestGuess, bestEntropy := game.BestGuess()
+		fmt.Printf("(Guess #%v) Best guess: %v (expected entropy: %v)\n", i+1, bestGuess, bestEntropy)
+		fmt.Printf("(Guess #%v) Guess:      %v\n", i+1, turn.Guess)
+		fmt.Printf("(Guess #%v) Hints:      %v\n", i+1, strings.Join(turn.Hints, " "))
+
+		remaining, err := game.Apply(turn.Guess, turn.Hints)
+		if err != nil {
+			return fmt.Errorf("guess #%v: %w", i+1, err)
+		}
+
+		fmt.Printf("(Guess #%v) Remaining:  %v\n", i+1, remaining)
+		fmt.Println()
+	}
+
+	if game.Solved() {
+		fmt.Println("Every board is solved!")
+		return nil
+	}
+
+	bestGuess, bestEntropy := game.BestGuess()
+	fmt.Printf("Next best guess: %v (expected entropy: %v)\n", bestGuess, bestEntropy)
+
+	return nil
 }
@@ -0,0 +1,107 @@
+package wordle
+
+// Messages holds the prompts and labels shown while playing a game, e.g. to show them in another language. Empty
+// messages are shown in English. Verbose output isn't included.
+//
+// Some messages are formats for fmt.Sprintf, filled in with the values their comment lists (e.g. "Guess %v to win").
+// Use explicit argument indexes, like %[2]v, to show the values in a different order.
+type Messages struct {
+	// Shown before the best guess, e.g. "Best guess: tares".
+	BestGuess string
+
+	// The prompts for typing a guess and a hint.
+	GuessPrompt, HintPrompt string
+
+	// Shown when the best guess or the typed guess (as a hint) are used.
+	UsedBestGuess, UsedGuessAsHint string
+
+	// Shown before the reason a typed guess, answer (see the "!word" command) or hint can't be used.
+	BadGuess, BadAnswer, BadHint string
+
+	// Shown before a typed guess which can't rule out any possible answer. See GameOptions.RefuseUselessGuesses.
+	UselessGuess string
+
+	// Shown before the only possible answer left, when guessing it to confirm it. See GameOptions.ConfirmFinal.
+	ConfirmFinal string
+
+	// Shown before the best guess when listing the best guesses (the "top" command) if it was looked up rather than
+	// calculated, so the other words haven't been ranked.
+	NotRanked string
+
+	// Format shown when the "mode" command is given an unknown mode, filled in with the mode and the known modes.
+	UnknownMode string
+
+	// Format shown when a hint leaves no possible answers and an earlier hint is ignored because of it (see
+	// GameOptions.Recover), filled in with the latest hint, the ignored hint and the guess it was for.
+	IgnoredHint string
+
+	// Shown at the end of a game played by a person which ended with one possible answer left, rather than by guessing
+	// it: GuessToWin is a format filled in with the answer, and OnlyWordLeft explains why it's certainly the answer.
+	GuessToWin, OnlyWordLeft string
+
+	// Shown with the percentage done while the best guess is being calculated in a game played by a person.
+	Calculating string
+
+	// Shown when no guesses can guarantee winning within the guesses left, however the hints turn out.
+	NeedsLuck string
+
+	// Shown before the answer and the number of guesses at the end of the game.
+	Answer, Guesses string
+}
+
+// The messages used unless others are configured.
+var defaultMessages = Messages{
+	BestGuess:       "Best guess",
+	GuessPrompt:     "Guess",
+	HintPrompt:      "Hint",
+	UsedBestGuess:   "Used best guess",
+	UsedGuessAsHint: "Used guess as hint",
+	BadGuess:        "Bad guess",
+	BadAnswer:       "Bad answer",
+	BadHint:         "Bad hint",
+	UselessGuess:    "Useless guess",
+	ConfirmFinal:    "One possible answer left, guess it to confirm",
+	NotRanked:       "The best guess was looked up, the other words haven't been ranked. Best guess",
+	UnknownMode:     "Unknown mode %v, use one of: %v",
+	IgnoredHint:     "The hint %v left no possible answers, so the hint %v for %v must have been wrong. Ignoring it.",
+	GuessToWin:      ">>> Guess %v to win! <<<",
+	OnlyWordLeft:    "It's the only word left, so it's certainly the answer - as long as the hints were typed correctly.",
+	Calculating:     "Calculating best guess",
+	NeedsLuck:       "No guesses can guarantee winning in time now, some luck is needed",
+	Answer:          "Answer",
+	Guesses:         "Guesses",
+}
+
+// withDefaults returns m, with empty messages replaced by the default ones.
+func (m Messages) withDefaults() Messages {
+	for _, message := range []struct {
+		value    *string
+		fallback string
+	}{
+		{&m.BestGuess, defaultMessages.BestGuess},
+		{&m.GuessPrompt, defaultMessages.GuessPrompt},
+		{&m.HintPrompt, defaultMessages.HintPrompt},
+		{&m.UsedBestGuess, defaultMessages.UsedBestGuess},
+		{&m.UsedGuessAsHint, defaultMessages.UsedGuessAsHint},
+		{&m.BadGuess, defaultMessages.BadGuess},
+		{&m.BadAnswer, defaultMessages.BadAnswer},
+		{&m.BadHint, defaultMessages.BadHint},
+		{&m.UselessGuess, defaultMessages.UselessGuess},
+		{&m.ConfirmFinal, defaultMessages.ConfirmFinal},
+		{&m.NotRanked, defaultMessages.NotRanked},
+		{&m.UnknownMode, defaultMessages.UnknownMode},
+		{&m.IgnoredHint, defaultMessages.IgnoredHint},
+		{&m.GuessToWin, defaultMessages.GuessToWin},
+		{&m.OnlyWordLeft, defaultMessages.OnlyWordLeft},
+		{&m.Calculating, defaultMessages.Calculating},
+		{&m.NeedsLuck, defaultMessages.NeedsLuck},
+		{&m.Answer, defaultMessages.Answer},
+		{&m.Guesses, defaultMessages.Guesses},
+	} {
+		if *message.value == "" {
+			*message.value = message.fallback
+		}
+	}
+
+	return m
+}
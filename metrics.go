@@ -0,0 +1,38 @@
+package wordle
+
+import "time"
+
+// Metrics records observations about games, e.g. to export them to a monitoring system like Prometheus without the
+// package depending on it. See GameOptions.Metrics.
+type Metrics interface {
+	// Observe records value as an observation of the metric called name. It must be safe for concurrent use if games
+	// sharing it are played concurrently, e.g. by SolveBatch.
+	Observe(name string, value float64)
+}
+
+// Names of the metrics observed if GameOptions.Metrics is set.
+const (
+	// MetricGuesses is the number of guesses a game took, observed when it ends.
+	MetricGuesses = "wordle_game_guesses"
+
+	// MetricSolveSeconds is how long a game took to play, in seconds, observed when it ends. For games played by a
+	// person, this includes the time spent typing guesses and hints.
+	MetricSolveSeconds = "wordle_game_solve_seconds"
+
+	// MetricBestGuessSeconds is how long calculating the best guess took, in seconds, observed each time it's
+	// calculated. Best guesses which are looked up instead (e.g. the cached first guess) aren't observed.
+	MetricBestGuessSeconds = "wordle_best_guess_seconds"
+)
+
+// observe records value for the metric called name to GameOptions.Metrics, if set.
+func (g *Game) observe(name string, value float64) {
+	if g.options.Metrics != nil {
+		g.options.Metrics.Observe(name, value)
+	}
+}
+
+// observeGame records the metrics of a game which ended with result, having been played since start.
+func (g *Game) observeGame(result GameResult, start time.Time) {
+	g.observe(MetricGuesses, float64(result.Guesses))
+	g.observe(MetricSolveSeconds, time.Since(start).Seconds())
+}
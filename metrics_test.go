@@ -0,0 +1,89 @@
+package wordle
+
+import (
+	"sync"
+	"testing"
+)
+
+// capturedMetrics is a Metrics which keeps every observation, by metric name.
+type capturedMetrics struct {
+	mu           sync.Mutex
+	observations map[string][]float64
+}
+
+func (c *capturedMetrics) Observe(name string, value float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.observations == nil {
+		c.observations = map[string][]float64{}
+	}
+	c.observations[name] = append(c.observations[name], value)
+}
+
+func TestMetrics(t *testing.T) {
+	metrics := &capturedMetrics{}
+
+	g, err := NewGame(GameOptions{Dictionary: ValidWords[:300], Answer: "moist", FirstGuess: "chill", Metrics: metrics})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := g.solve()
+
+	if guesses := metrics.observations[MetricGuesses]; len(guesses) != 1 || guesses[0] != float64(result.Guesses) {
+		t.Errorf("observed guesses %v, want just %v", guesses, result.Guesses)
+	}
+
+	if seconds := metrics.observations[MetricSolveSeconds]; len(seconds) != 1 || seconds[0] < 0 {
+		t.Errorf("observed solve seconds %v, want one time", seconds)
+	}
+
+	// The first guess is looked up rather than calculated.
+	if seconds, calculated := metrics.observations[MetricBestGuessSeconds], len(g.turns)-1; len(seconds) != calculated {
+		t.Errorf("observed best guess seconds %v times, want %v", len(seconds), calculated)
+	}
+}
+
+func TestMetricsPlay(t *testing.T) {
+	defer quiet()()
+
+	dictionary := []string{"bills", "fills", "hills", "crane"}
+
+	// Other tests rely on the first guess for this dictionary not being cached yet.
+	defer forgetOpener(newOpenerKey(dictionary, nil))
+
+	metrics := &capturedMetrics{}
+	playInput(t, GameOptions{Dictionary: dictionary, Metrics: metrics}, "bills\nbgggg\nfills\nggggg\n")
+
+	if guesses := metrics.observations[MetricGuesses]; len(guesses) != 1 || guesses[0] != 2 {
+		t.Errorf("observed guesses %v, want just 2", guesses)
+	}
+
+	if seconds := metrics.observations[MetricSolveSeconds]; len(seconds) != 1 {
+		t.Errorf("observed solve seconds %v, want one time", seconds)
+	}
+}
+
+func TestMetricsSolveBatch(t *testing.T) {
+	metrics := &capturedMetrics{}
+	answers := ValidWords[:20]
+
+	results := SolveBatch(GameOptions{Dictionary: ValidWords[:300], Metrics: metrics}, answers, 4)
+
+	guesses := metrics.observations[MetricGuesses]
+	if len(guesses) != len(answers) || len(metrics.observations[MetricSolveSeconds]) != len(answers) {
+		t.Fatalf("observed guesses %v times and solve seconds %v times for %v games", len(guesses),
+			len(metrics.observations[MetricSolveSeconds]), len(answers))
+	}
+
+	var observed, want float64
+	for i, result := range results {
+		observed += guesses[i]
+		want += float64(result.Guesses)
+	}
+
+	if observed != want {
+		t.Errorf("observed %v guesses in total, want %v", observed, want)
+	}
+}
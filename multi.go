@@ -0,0 +1,166 @@
+package wordle
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+// A MultiGame is a game with several boards which are played at the same time, e.g. Quordle. Each guess is made on
+// every board, and each board has its own answer. Like Game, the answers are narrowed down by the hints of each board.
+type MultiGame struct {
+	boards []*Game
+
+	// solved holds whether each board was solved, i.e. whether a guess got an all correct hint on it.
+	solved []bool
+}
+
+// NewMultiGame creates a game with the given number of boards, each of which is configured by options. The answers are
+// unknown, so options.Answer is ignored. If options.DumpDir is set, each board dumps to its own subdirectory of it,
+// e.g. board_1 for the first. It returns an error if the options can't be used to play a game.
+func NewMultiGame(options GameOptions, boards int) (*MultiGame, error) {
+	if boards <= 0 {
+		return nil, fmt.Errorf("there must be at least one board, got %v", boards)
+	}
+
+	options.Answer = ""
+
+	m := &MultiGame{solved: make([]bool, boards)}
+	for i := 0; i < boards; i++ {
+		boardOptions := options
+		if options.DumpDir != "" {
+			boardOptions.DumpDir = filepath.Join(options.DumpDir, fmt.Sprintf("board_%v", i+1))
+		}
+
+		g, err := NewGame(boardOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		m.boards = append(m.boards, g)
+	}
+
+	return m, nil
+}
+
+// Apply narrows down the possible answers of each board using the hint guess resulted in on it. The hint of a board
+// which was already solved is ignored, and can be SolvedPlaceholder. It returns the number of possible answers left
+// on each board.
+//
+// It returns an error, leaving the game unchanged, if the guess or any hint is invalid, or if no answers would be left
+// on a board.
+func (m *MultiGame) Apply(guess string, hints []string) ([]int, error) {
+	if len(hints) != len(m.boards) {
+		return nil, fmt.Errorf("expected %v hints, got %v", len(m.boards), len(hints))
+	}
+
+	before := make([]Game, len(m.boards))
+	for i, board := range m.boards {
+		before[i] = *board
+	}
+
+	solved := append([]bool(nil), m.solved...)
+	for i, board := range m.boards {
+		if m.solved[i] {
+			continue
+		}
+
+		var err error
+		if hints[i] == SolvedPlaceholder {
+			err = errors.New("the board isn't solved yet")
+		} else {
+			_, _, err = board.Apply(guess, hints[i])
+		}
+
+		if err != nil {
+			for j := range m.boards {
+				*m.boards[j] = before[j]
+			}
+
+			return nil, fmt.Errorf("board %v: %w", i+1, err)
+		}
+
+		// The hint was validated by Apply.
+		var hint wordHint
+		_ = hint.fromString(hints[i])
+		solved[i] = hint.solved()
+	}
+
+	m.solved = solved
+
+	return m.Remaining(), nil
+}
+
+// Remaining returns the number of possible answers left on each board. Solved boards have one.
+func (m *MultiGame) Remaining() []int {
+	result := make([]int, len(m.boards))
+	for i, board := range m.boards {
+		result[i] = len(board.dictionary)
+	}
+
+	return result
+}
+
+// Solved returns whether every board has been solved.
+func (m *MultiGame) Solved() bool {
+	for _, solved := range m.solved {
+		if !solved {
+			return false
+		}
+	}
+
+	return true
+}
+
+// BestGuess returns the best guess to make on every board at this stage of the game, along with its entropy: the sum
+// of its entropy on each board which isn't solved yet.
+//
+// If a board which isn't solved has only one possible answer left, it's guessed, since it has to be guessed at some
+// point anyway. Otherwise, the possible answers of every board which isn't solved are scored, and the best is chosen.
+// If nothing has been guessed yet, every board is the same, so the best guess is that of a single board.
+func (m *MultiGame) BestGuess() (string, float64) {
+	if len(m.boards[0].turns) == 0 {
+		guess, entropy := m.boards[0].BestGuess()
+		return guess, entropy * float64(len(m.boards))
+	}
+
+	var candidates []string
+	seen := map[string]bool{}
+	for i, board := range m.boards {
+		if m.solved[i] {
+			continue
+		}
+
+		if len(board.dictionary) == 1 {
+			return board.dictionary[0], m.entropy(board.dictionary[0])
+		}
+
+		for _, word := range board.dictionary {
+			if !seen[word] {
+				seen[word] = true
+				candidates = append(candidates, word)
+			}
+		}
+	}
+
+	var best ScoredGuess
+	for i, word := range candidates {
+		if entropy := m.entropy(word); i == 0 || entropy > best.Score {
+			best = ScoredGuess{Word: word, Score: entropy}
+		}
+	}
+
+	return best.Word, best.Score
+}
+
+// entropy returns the sum of the entropy of guessing word on each board which isn't solved yet.
+func (m *MultiGame) entropy(word string) float64 {
+	sum := 0.0
+	for i, board := range m.boards {
+		if !m.solved[i] {
+			sum += board.entropy(word)
+		}
+	}
+
+	return sum
+}
@@ -0,0 +1,89 @@
+package wordle
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMultiGameSolve(t *testing.T) {
+	defer quiet()()
+
+	options := GameOptions{Dictionary: ValidWords[:500]}
+	answers := []string{options.Dictionary[10], options.Dictionary[100], options.Dictionary[250], options.Dictionary[400]}
+
+	// Play the game, writing it down as a transcript.
+	m, err := NewMultiGame(options, len(answers))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var transcript strings.Builder
+	for turn := 0; !m.Solved(); turn++ {
+		if turn == 20 {
+			t.Fatalf("boards still aren't solved after %v guesses", turn)
+		}
+
+		guess, _ := m.BestGuess()
+
+		hints := make([]string, len(answers))
+		for i, answer := range answers {
+			hints[i] = SolvedPlaceholder
+			if !m.solved[i] {
+				hints[i] = createHint(guess, answer).String()
+			}
+		}
+
+		if _, err := m.Apply(guess, hints); err != nil {
+			t.Fatal(err)
+		}
+
+		fmt.Fprintf(&transcript, "%v %v\n", guess, strings.Join(hints, " "))
+	}
+
+	for i, board := range m.boards {
+		if len(board.dictionary) != 1 || board.dictionary[0] != answers[i] {
+			t.Errorf("board %v was solved with %v left, want %v", i+1, board.dictionary, answers[i])
+		}
+	}
+
+	// Replaying the transcript solves every board the same way.
+	turns, err := ParseMultiTranscript(strings.NewReader(transcript.String()), len(answers))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replay, err := NewMultiGame(options, len(answers))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, turn := range turns {
+		if _, err := replay.Apply(turn.Guess, turn.Hints); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !replay.Solved() || fmt.Sprint(replay.Remaining()) != "[1 1 1 1]" {
+		t.Errorf("replaying the transcript left %v, want every board solved", replay.Remaining())
+	}
+}
+
+func TestMultiGameApplyErrors(t *testing.T) {
+	m, err := NewMultiGame(GameOptions{Dictionary: ValidWords[:500]}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Apply("tares", []string{"bbbbb"}); err == nil {
+		t.Error("applying too few hints didn't return an error")
+	}
+
+	if _, err := m.Apply("tares", []string{"bbbbb", SolvedPlaceholder}); err == nil {
+		t.Error("applying the solved placeholder to an unsolved board didn't return an error")
+	}
+
+	if remaining := m.Remaining(); remaining[0] != 500 || remaining[1] != 500 {
+		t.Errorf("a failed apply changed the game, leaving %v", remaining)
+	}
+}
@@ -0,0 +1,185 @@
+package wordle
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The best first guess for ValidWords and its entropy, as calculated by getBestGuess without the cache.
+//
+// These must be recalculated whenever ValidWords or the entropy calculation changes, along with bumping
+// openerCacheVersion.
+const (
+	cachedFirstGuess        = "tares"
+	cachedFirstGuessEntropy = 6.194052544375446
+)
+
+// An openerKey identifies the words a game starts with, which are all the best first guess depends on.
+type openerKey struct {
+	wordLength int
+
+	// words is a hash of the dictionary and the guess-only words.
+	words uint64
+}
+
+// newOpenerKey returns the key of a game starting with dictionary and guessOnly.
+func newOpenerKey(dictionary, guessOnly []string) openerKey {
+	h := fnv.New64a()
+
+	for _, words := range [][]string{dictionary, guessOnly} {
+		for _, word := range words {
+			h.Write([]byte(word))
+			h.Write([]byte{'\n'})
+		}
+
+		// Separate the lists, so that moving a word from one to the other changes the hash.
+		h.Write([]byte{0})
+	}
+
+	return openerKey{
+		wordLength: wordSize,
+		words:      h.Sum64(),
+	}
+}
+
+// An openerTable holds the best first guess for each of the dictionaries it's seen, along with its entropy. It's safe
+// for concurrent use.
+type openerTable struct {
+	mu      sync.Mutex
+	openers map[openerKey]ScoredGuess
+}
+
+// get returns the best first guess for key, if it's known.
+func (o *openerTable) get(key openerKey) (ScoredGuess, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	opener, ok := o.openers[key]
+	return opener, ok
+}
+
+// set records opener as the best first guess for key.
+func (o *openerTable) set(key openerKey, opener ScoredGuess) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.openers[key] = opener
+}
+
+// openers holds the best first guesses calculated so far, so that they're only calculated once per dictionary. The
+// best first guess for ValidWords takes a long time to calculate, so it's always known.
+var openers = &openerTable{
+	openers: map[openerKey]ScoredGuess{
+		newOpenerKey(ValidWords, nil): {Word: cachedFirstGuess, Score: cachedFirstGuessEntropy},
+	},
+}
+
+// The version of the entropy calculation the openers in saved opener caches were calculated with. Caches saved with a
+// different version are stale, and ignored when loaded.
+const openerCacheVersion = 2
+
+// The first line of a saved opener cache, followed by its version.
+const openerCacheHeader = "wordle opener cache"
+
+// SaveOpenerCache saves the best first guesses calculated so far to the file at path, so that they can be loaded with
+// LoadOpenerCache instead of being calculated again, e.g. the next time the program runs.
+func SaveOpenerCache(path string) error {
+	openers.mu.Lock()
+	lines := make([]string, 0, len(openers.openers))
+	for key, opener := range openers.openers {
+		lines = append(lines, fmt.Sprintf("%v %x %v %v", key.wordLength, key.words, opener.Word, strconv.FormatFloat(opener.Score, 'g', -1, 64)))
+	}
+	openers.mu.Unlock()
+
+	sort.Strings(lines)
+
+	contents := fmt.Sprintf("%v %v\n%v\n", openerCacheHeader, openerCacheVersion, strings.Join(lines, "\n"))
+	return os.WriteFile(path, []byte(contents), 0644)
+}
+
+// LoadOpenerCache loads the best first guesses saved to the file at path by SaveOpenerCache, so that they're used
+// instead of being calculated. Each is only used for the dictionary it was calculated for.
+//
+// If the cache was saved by a version of the solver which calculates entropy differently, it's stale, and nothing is
+// loaded. It returns an error if the file can't be read or isn't an opener cache.
+func LoadOpenerCache(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("%v: empty file, not an opener cache", path)
+	}
+
+	header := scanner.Text()
+	if !strings.HasPrefix(header, openerCacheHeader+" ") {
+		return fmt.Errorf("%v: not an opener cache", path)
+	}
+	version := strings.TrimPrefix(header, openerCacheHeader+" ")
+
+	if version != strconv.Itoa(openerCacheVersion) {
+		return nil
+	}
+
+	loaded := map[openerKey]ScoredGuess{}
+	for lineNum := 2; scanner.Scan(); lineNum++ {
+		key, opener, err := parseOpener(scanner.Text())
+		if err != nil {
+			return fmt.Errorf("%v: line %v: %w", path, lineNum, err)
+		}
+
+		loaded[key] = opener
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	for key, opener := range loaded {
+		openers.set(key, opener)
+	}
+
+	return nil
+}
+
+// parseOpener parses a line of a saved opener cache.
+func parseOpener(line string) (openerKey, ScoredGuess, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 4 {
+		return openerKey{}, ScoredGuess{}, fmt.Errorf("expected 4 fields, got %v", len(fields))
+	}
+
+	wordLength, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return openerKey{}, ScoredGuess{}, fmt.Errorf("bad word length: %w", err)
+	}
+
+	words, err := strconv.ParseUint(fields[1], 16, 64)
+	if err != nil {
+		return openerKey{}, ScoredGuess{}, fmt.Errorf("bad dictionary hash: %w", err)
+	}
+
+	if len(fields[2]) != wordLength {
+		return openerKey{}, ScoredGuess{}, fmt.Errorf("guess %v isn't %v letters long", fields[2], wordLength)
+	}
+
+	entropy, err := strconv.ParseFloat(fields[3], 64)
+	if err != nil {
+		return openerKey{}, ScoredGuess{}, fmt.Errorf("bad entropy: %w", err)
+	}
+
+	return openerKey{wordLength: wordLength, words: words}, ScoredGuess{Word: fields[2], Score: entropy}, nil
+}
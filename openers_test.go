@@ -0,0 +1,135 @@
+package wordle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenerPerDictionary(t *testing.T) {
+	defer quiet()()
+
+	// Words are only five letters long, so a dictionary without tares stands in for another word length.
+	dictionary := []string{"bills", "fills", "hills", "crane", "crate", "moist"}
+
+	g, err := NewGame(GameOptions{Dictionary: dictionary})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	guess, entropy := g.BestGuess()
+	if !g.isPossible(guess) {
+		t.Fatalf("opener %v isn't in the dictionary", guess)
+	}
+
+	opener, ok := openers.get(newOpenerKey(dictionary, nil))
+	if !ok || opener.Word != guess || opener.Score != entropy {
+		t.Errorf("cached opener is %v, %v, want %v (%v)", opener, ok, guess, entropy)
+	}
+
+	// Adding a guess-only word makes it a different dictionary.
+	if _, ok := openers.get(newOpenerKey(dictionary, []string{"tares"})); ok {
+		t.Error("guess-only words don't change the opener's key")
+	}
+}
+
+// forgetOpener removes the cached opener for key, as if it had never been calculated.
+func forgetOpener(key openerKey) {
+	openers.mu.Lock()
+	defer openers.mu.Unlock()
+
+	delete(openers.openers, key)
+}
+
+func TestOpenerCacheRoundTrip(t *testing.T) {
+	defer quiet()()
+
+	dictionary := ValidWords[100:400]
+	key := newOpenerKey(dictionary, nil)
+
+	g, err := NewGame(GameOptions{Dictionary: dictionary})
+	if err != nil {
+		t.Fatal(err)
+	}
+	guess, entropy := g.BestGuess()
+
+	path := filepath.Join(t.TempDir(), "openers")
+	if err := SaveOpenerCache(path); err != nil {
+		t.Fatal(err)
+	}
+
+	forgetOpener(key)
+
+	if err := LoadOpenerCache(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if opener, ok := openers.get(key); !ok || opener.Word != guess || opener.Score != entropy {
+		t.Errorf("loaded opener is %v, %v, want %v (%v)", opener, ok, guess, entropy)
+	}
+
+	// The solver uses the loaded opener instead of calculating it.
+	if err := os.WriteFile(path, []byte(fmt.Sprintf("%v %v\n%v %x moist 42\n", openerCacheHeader,
+		openerCacheVersion, key.wordLength, key.words)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer openers.set(key, ScoredGuess{Word: guess, Score: entropy})
+
+	if err := LoadOpenerCache(path); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err = NewGame(GameOptions{Dictionary: dictionary})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if guess, entropy := g.BestGuess(); guess != "moist" || entropy != 42 {
+		t.Errorf("best first guess is %v (%v), want the loaded moist (42)", guess, entropy)
+	}
+}
+
+func TestStaleOpenerCacheIgnored(t *testing.T) {
+	dictionary := ValidWords[400:700]
+	key := newOpenerKey(dictionary, nil)
+	forgetOpener(key)
+
+	path := filepath.Join(t.TempDir(), "openers")
+	if err := os.WriteFile(path, []byte(fmt.Sprintf("%v %v\n%v %x moist 42\n", openerCacheHeader,
+		openerCacheVersion-1, key.wordLength, key.words)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadOpenerCache(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if opener, ok := openers.get(key); ok {
+		t.Errorf("loaded opener %v from a stale cache", opener)
+	}
+}
+
+func TestLoadOpenerCacheErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, contents := range map[string]string{
+		"empty":       "",
+		"not a cache": "tares 6.19\n",
+		"bad line":    fmt.Sprintf("%v %v\nnot an opener\n", openerCacheHeader, openerCacheVersion),
+		"bad length":  fmt.Sprintf("%v %v\n5 ab12 tare 6.19\n", openerCacheHeader, openerCacheVersion),
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := LoadOpenerCache(path); err == nil {
+			t.Errorf("%v: loading didn't return an error", name)
+		}
+	}
+
+	if err := LoadOpenerCache(filepath.Join(dir, "missing")); err == nil {
+		t.Error("loading a missing file didn't return an error")
+	}
+}
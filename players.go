@@ -4,76 +4,202 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 )
 
 // A humanPlayer plays a Game by:
 // - manually typing the best guess into the game (shown through stdout)
 // - entering the resulting hint through stdin
+//
+// Instead of a guess, "top" can be entered to list the best guesses, "!word" to guess word knowing it's the answer,
+// ending the game without entering a hint, and "mode name" to change how the best guess is chosen (see modes).
 type humanPlayer struct {
+	game        *Game
 	guessAsHint *wordHint
 }
 
+// The number of guesses listed by the "top" command.
+const topGuessCount = 10
+
 func (h *humanPlayer) getGuess(bestGuess string) string {
 	if !Verbose {
-		fmt.Println("Best guess:", bestGuess)
+		fmt.Println(h.messages().BestGuess+":", bestGuess)
 	}
 
 	for {
-		result := readLine("Guess")
+		result := readLine(h.messages().GuessPrompt)
 		if len(result) == 0 {
-			fmt.Println("Used best guess")
+			fmt.Println(h.messages().UsedBestGuess)
 			return bestGuess
 		}
 
-		if len(result) != wordSize {
-			fmt.Printf("Bad guess: wrong size: expected %v, got %v\n", wordSize, len(result))
+		if result == "top" {
+			h.printTopGuesses(bestGuess)
+			continue
+		}
+
+		if strings.HasPrefix(result, "mode ") {
+			name := strings.TrimSpace(strings.TrimPrefix(result, "mode "))
+			mode, ok := modes[name]
+			if !ok {
+				fmt.Printf(h.messages().UnknownMode+"\n", name, strings.Join(modeNames(), ", "))
+				continue
+			}
+
+			mode(h.game)
+			bestGuess, _ = h.game.getBestGuess(len(h.game.turns) == 0)
+			fmt.Println(h.messages().BestGuess+":", bestGuess)
 			continue
 		}
 
+		if strings.HasPrefix(result, "!") {
+			answer := result[1:]
+			if err := h.checkAnswer(answer); err != nil {
+				fmt.Printf("%v: %v\n", h.messages().BadAnswer, err)
+				continue
+			}
+
+			var solved wordHint
+			for i := range solved {
+				solved[i] = Correct
+			}
+			h.guessAsHint = &solved
+
+			return answer
+		}
+
 		var hint wordHint
 		if hint.fromString(result) == nil {
 			h.guessAsHint = &hint
-			fmt.Println("Used best guess")
+			fmt.Println(h.messages().UsedBestGuess)
 			return bestGuess
 		}
 
+		if err := checkWord(result); err != nil {
+			fmt.Printf("%v: %v\n", h.messages().BadGuess, err)
+			continue
+		}
+
+		if h.game.useless(result) {
+			fmt.Printf("%v: %v gives the same hint for every possible answer\n", h.messages().UselessGuess, result)
+			if h.game.options.RefuseUselessGuesses {
+				continue
+			}
+		}
+
 		return result
 	}
 }
 
+// modes holds the ways of choosing the best guess which can be switched to with the "mode" command, by name.
+var modes = map[string]func(g *Game){
+	"entropy": func(g *Game) { g.SetStrategy(EntropyStrategy) },
+	"minimax": func(g *Game) { g.SetStrategy(MinimaxStrategy) },
+	"answers": func(g *Game) { g.SetAnswersOnly(true) },
+	"any":     func(g *Game) { g.SetAnswersOnly(false) },
+}
+
+// modeNames returns the names of modes, in alphabetical order.
+func modeNames() []string {
+	names := make([]string, 0, len(modes))
+	for name := range modes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// checkAnswer returns an error if answer can't be the answer given the hints entered so far.
+func (h *humanPlayer) checkAnswer(answer string) error {
+	if err := checkWord(answer); err != nil {
+		return err
+	}
+
+	if h.game.isPossible(answer) {
+		return nil
+	}
+
+	return fmt.Errorf("%v isn't a possible answer given the hints so far", answer)
+}
+
+// messages returns the messages to show the player.
+func (h *humanPlayer) messages() Messages {
+	return h.game.options.Messages.withDefaults()
+}
+
+// printTopGuesses prints the best guesses at this stage of the game.
+func (h *humanPlayer) printTopGuesses(bestGuess string) {
+	// The cached first guess and guesses from GameOptions.DecisionTree are the only ones which don't score every word,
+	// and scoring every word in a large dictionary takes too long to do on demand.
+	if h.game.scores == nil {
+		fmt.Println(h.messages().NotRanked+":", bestGuess)
+		return
+	}
+
+	for i, guess := range h.game.BestGuesses(topGuessCount) {
+		fmt.Printf("%v. %v (entropy: %v)\n", i+1, guess.Word, guess.Score)
+	}
+}
+
 func (h *humanPlayer) getHint(guess string) wordHint {
 	var hint wordHint
 
 	if h.guessAsHint != nil {
-		fmt.Println("Used guess as hint")
 		hint = *h.guessAsHint
 		h.guessAsHint = nil
+
+		// The hint of a known answer isn't worth mentioning.
+		if !hint.solved() {
+			fmt.Println(h.messages().UsedGuessAsHint)
+		}
 		return hint
 	}
 
 	for {
-		result := readLine("Hint")
+		result := readLine(h.messages().HintPrompt)
 
 		err := hint.fromString(result)
 		if err == nil {
 			return hint
 		}
 
-		fmt.Printf("Bad hint: %v\n", err)
+		fmt.Printf("%v: %v\n", h.messages().BadHint, err)
 	}
 }
 
+// stdin is shared between calls to readLine, since buffered input would be lost otherwise (e.g. when it's piped in).
+var stdin = bufio.NewReader(os.Stdin)
+
 func readLine(prompt string) string {
-	reader := bufio.NewReader(os.Stdin)
 	fmt.Print(prompt + ": ")
-	text, err := reader.ReadString('\n')
+	text, err := stdin.ReadString('\n')
 	if err != nil {
 		panic(err)
 	}
 	return strings.TrimSpace(text)
 }
 
+// newProgressPrinter returns a function which shows label along with the percentage done on a single line, updating it
+// in place, and clears the line once done reaches 1.
+func newProgressPrinter(label string) func(done float64) {
+	shown := -1
+	return func(done float64) {
+		percent := int(done * 100)
+		if percent == shown {
+			return
+		}
+		shown = percent
+
+		if done >= 1 {
+			fmt.Printf("\r%s\r", strings.Repeat(" ", len(label)+len("... 100%")))
+			return
+		}
+		fmt.Printf("\r%s... %d%%", label, percent)
+	}
+}
+
 // A computerPlayer plays a Game by:
 // - using the best guess
 // - calculating the hint by comparing against the answer
@@ -0,0 +1,294 @@
+package wordle
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// playInput plays a game with options as a person whose input is input, returning the result and what was printed.
+func playInput(t *testing.T, options GameOptions, input string) (GameResult, string) {
+	t.Helper()
+
+	g, err := NewGame(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	previous := stdin
+	stdin = bufio.NewReader(strings.NewReader(input))
+	defer func() {
+		stdin = previous
+	}()
+
+	var result GameResult
+	output := captureOutput(t, func() {
+		result = g.Play()
+	})
+
+	return result, output
+}
+
+func TestTopCommand(t *testing.T) {
+	defer quiet()()
+
+	result, output := playInput(t, GameOptions{Dictionary: []string{"bills", "fills", "hills", "crane"}},
+		"top\nbills\nggggg\n")
+
+	if !strings.Contains(output, "1. bills (entropy: 1.5)") || !strings.Contains(output, "4. crane (entropy: ") {
+		t.Errorf("top didn't list the best guesses:\n%v", output)
+	}
+
+	if result.Guesses != 1 || result.Turns[0].Guess != "bills" {
+		t.Errorf("top used up a turn: %v", result.Turns)
+	}
+}
+
+func TestTopCommandCachedFirstGuess(t *testing.T) {
+	defer quiet()()
+
+	_, output := playInput(t, GameOptions{}, "top\n\nggggg\n")
+
+	if !strings.Contains(output, defaultMessages.NotRanked+": "+cachedFirstGuess) {
+		t.Errorf("top didn't say the cached first guess wasn't ranked:\n%v", output)
+	}
+}
+
+func TestConfirmFinal(t *testing.T) {
+	defer quiet()()
+
+	options := GameOptions{Dictionary: []string{"bills", "fills", "hills"}, ConfirmFinal: true}
+
+	result, output := playInput(t, options, "bills\nbgggg\nfills\nbgggg\nhills\nggggg\n")
+	if !strings.Contains(output, defaultMessages.ConfirmFinal+": hills") {
+		t.Errorf("didn't ask to confirm the only word left:\n%v", output)
+	}
+
+	if result.Answer != "hills" || result.Guesses != 3 {
+		t.Errorf("got %v in %v guesses, want hills in 3", result.Answer, result.Guesses)
+	}
+}
+
+func TestConfirmFinalMismatch(t *testing.T) {
+	defer quiet()()
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "didn't confirm") {
+			t.Errorf("an unconfirmed answer panicked with %v, want it to say it wasn't confirmed", r)
+		}
+	}()
+
+	playInput(t, GameOptions{Dictionary: []string{"bills", "fills"}, ConfirmFinal: true}, "bills\nbgggg\nfills\nbgggg\n")
+}
+
+func TestOnlyWordLeft(t *testing.T) {
+	defer quiet()()
+
+	result, output := playInput(t, GameOptions{Dictionary: []string{"bills", "fills", "hills"}}, "bills\nbgggg\nfills\nbgggg\n")
+
+	win := strings.Index(output, fmt.Sprintf(defaultMessages.GuessToWin, "hills"))
+	answer := strings.Index(output, defaultMessages.Answer+":")
+	if win == -1 || answer < win || !strings.Contains(output, defaultMessages.OnlyWordLeft) {
+		t.Errorf("didn't say to guess the only word left before the answer:\n%v", output)
+	}
+
+	if result.Answer != "hills" || result.Guesses != 3 {
+		t.Errorf("got %v in %v guesses, want hills in 3", result.Answer, result.Guesses)
+	}
+}
+
+func TestGuessKnownAnswer(t *testing.T) {
+	defer quiet()()
+
+	result, output := playInput(t, GameOptions{Dictionary: []string{"bills", "fills", "hills"}},
+		"bills\nbgggg\n!bills\n!hills\n")
+
+	if !strings.Contains(output, defaultMessages.BadAnswer+": ") {
+		t.Errorf("guessing an answer the hints rule out wasn't refused:\n%v", output)
+	}
+
+	if result.Answer != "hills" || result.Guesses != 2 || result.Turns[1].Hint != "ggggg" {
+		t.Errorf("got %v in %v guesses (%v), want hills in 2", result.Answer, result.Guesses, result.Turns)
+	}
+}
+
+func TestRecoverFromMisreadHint(t *testing.T) {
+	defer quiet()()
+
+	// The answer is hills, but the hint of bills was misread as all absent, leaving only crane.
+	options := GameOptions{Dictionary: []string{"bills", "fills", "hills", "pills", "crane"}, ConfirmFinal: true,
+		Recover: true}
+
+	result, output := playInput(t, options, "bills\nbbbbb\ncrane\nbbbbb\nhills\nggggg\n")
+
+	if ignored := fmt.Sprintf(defaultMessages.IgnoredHint, "bbbbb", "bbbbb", "bills"); !strings.Contains(output, ignored) {
+		t.Errorf("didn't say the misread hint was ignored:\n%v", output)
+	}
+
+	if result.Answer != "hills" || result.Guesses != 3 {
+		t.Errorf("got %v in %v guesses, want hills in 3", result.Answer, result.Guesses)
+	}
+}
+
+func TestMessages(t *testing.T) {
+	defer quiet()()
+
+	messages := Messages{
+		BestGuess:   "Meilleur essai",
+		GuessPrompt: "Essai",
+		HintPrompt:  "Indice",
+		BadHint:     "Mauvais indice",
+		Answer:      "Réponse",
+		Guesses:     "Essais",
+	}
+
+	dictionary := []string{"bills", "fills", "hills", "crane"}
+
+	// Other tests rely on the first guess for this dictionary not being cached yet.
+	defer forgetOpener(newOpenerKey(dictionary, nil))
+
+	_, output := playInput(t, GameOptions{Dictionary: dictionary, Messages: messages}, "bills\nbbxbb\nggggg\n")
+
+	for _, message := range []string{"Meilleur essai", "Essai", "Indice", "Mauvais indice", "Réponse", "Essais"} {
+		if !strings.Contains(output, message) {
+			t.Errorf("output doesn't contain %q:\n%v", message, output)
+		}
+	}
+
+	for _, message := range []string{defaultMessages.BestGuess, defaultMessages.BadHint, defaultMessages.Answer} {
+		if strings.Contains(output, message) {
+			t.Errorf("output contains the overridden %q:\n%v", message, output)
+		}
+	}
+}
+
+func TestUselessGuess(t *testing.T) {
+	defer quiet()()
+
+	dictionary := []string{"bills", "fills", "hills", "mills", "crane"}
+
+	// After bills, crane gives the same hint whichever of fills, hills and mills is the answer.
+	result, output := playInput(t, GameOptions{Dictionary: dictionary}, "bills\nbgggg\ncrane\nbbbbb\nfills\nggggg\n")
+	if !strings.Contains(output, defaultMessages.UselessGuess) {
+		t.Errorf("guessing crane didn't warn that it's useless:\n%v", output)
+	}
+
+	if result.Guesses != 3 || result.Turns[1].Guess != "crane" {
+		t.Errorf("warning about crane didn't still guess it: %v", result.Turns)
+	}
+
+	result, output = playInput(t, GameOptions{Dictionary: dictionary, RefuseUselessGuesses: true},
+		"bills\nbgggg\ncrane\nfills\nggggg\n")
+	if !strings.Contains(output, defaultMessages.UselessGuess) {
+		t.Errorf("guessing crane didn't refuse it as useless:\n%v", output)
+	}
+
+	if result.Guesses != 2 || result.Turns[1].Guess != "fills" {
+		t.Errorf("refusing crane still guessed it: %v", result.Turns)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	defer quiet()()
+
+	dictionary := ValidWords[1000:2000]
+	forgetOpener(newOpenerKey(dictionary, nil))
+
+	var progress []float64
+	options := GameOptions{Dictionary: dictionary, OnProgress: func(done float64) {
+		progress = append(progress, done)
+	}}
+
+	bestGuess := func() bool {
+		g, err := NewGame(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		progress = nil
+		g.BestGuess()
+
+		return len(progress) != 0
+	}
+
+	// The first time, the opener is calculated, but then it's cached.
+	if !bestGuess() {
+		t.Error("calculating the opener didn't report progress")
+	} else if done := progress[len(progress)-1]; done != 1 {
+		t.Errorf("calculating the opener ended %v done, want 1", done)
+	}
+
+	if bestGuess() {
+		t.Errorf("looking up the cached opener reported progress %v", progress)
+	}
+
+	// Played by a person, the percentage done is only shown while calculating.
+	options.OnProgress = nil
+	_, output := playInput(t, options, dictionary[0]+"\n"+createHint(dictionary[0], dictionary[1]).String()+"\n"+
+		dictionary[1]+"\nggggg\n")
+
+	turns := strings.SplitN(output, defaultMessages.HintPrompt, 2)
+	if strings.Contains(turns[0], defaultMessages.Calculating) {
+		t.Errorf("the percentage done was shown when looking up the cached opener:\n%v", turns[0])
+	}
+
+	if !strings.Contains(turns[1], defaultMessages.Calculating+"... ") {
+		t.Errorf("the percentage done wasn't shown when calculating the second guess:\n%v", turns[1])
+	}
+}
+
+func TestModeCommand(t *testing.T) {
+	defer quiet()()
+
+	options := GameOptions{Dictionary: ValidWords[:300], Guesses: ValidWords[:3000]}
+
+	// Each mode changes the best guess after the first, which is split when choosing by entropy from any word.
+	result, output := playInput(t, options,
+		"crane\nbbbbb\nmode minimax\nmode answers\nmode entropy\nmode nope\n!moist\n")
+
+	rest := output
+	for _, guess := range []string{"split", "spilt", "stool", "moult"} {
+		i := strings.Index(rest, "Best guess: "+guess)
+		if i == -1 {
+			t.Fatalf("best guess %v wasn't shown after switching modes:\n%v", guess, output)
+		}
+
+		rest = rest[i:]
+	}
+
+	if !strings.Contains(rest, "Unknown mode nope, use one of: answers, any, entropy, minimax") {
+		t.Errorf("an unknown mode wasn't reported:\n%v", output)
+	}
+
+	if result.Answer != "moist" || result.Guesses != 2 {
+		t.Errorf("game ended with %v in %v guesses, want moist in 2", result.Answer, result.Guesses)
+	}
+
+	// The strategy the game was switched to is used from then on, just like if it was chosen from the start.
+	g, err := NewGame(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := g.Apply("crane", "bbbbb"); err != nil {
+		t.Fatal(err)
+	}
+
+	g.SetStrategy(MinimaxStrategy)
+	switched, _ := g.BestGuess()
+
+	options.Strategy = MinimaxStrategy
+	g, err = NewGame(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := g.Apply("crane", "bbbbb"); err != nil {
+		t.Fatal(err)
+	}
+
+	if chosen, _ := g.BestGuess(); switched != chosen {
+		t.Errorf("best guess after switching to minimax is %v, want %v like choosing it from the start", switched, chosen)
+	}
+}
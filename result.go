@@ -0,0 +1,192 @@
+package wordle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The number of guesses allowed in a standard game of Wordle.
+const defaultMaxGuesses = 6
+
+// A GameResult is the outcome of a game of Wordle.
+type GameResult struct {
+	Answer  string
+	Guesses int
+
+	// Turns holds each guess made, ending with guessing the answer. If the game ended because the answer was the only
+	// word left, guessing it is included even though it wasn't played.
+	Turns []Turn
+
+	// Confidence is the probability that Answer is the answer, given the hints revealed. It's 1 once the answer is the
+	// only word left. See Game.CandidateProbabilities.
+	Confidence float64
+
+	// PuzzleNumber and MaxGuesses are the values given in GameOptions, with defaults filled in.
+	PuzzleNumber int
+	MaxGuesses   int
+
+	// options are the options the game was played with, for GameResult.MarshalDocument.
+	options GameOptions
+}
+
+// result returns the result of g, which must be over.
+func (g *Game) result() GameResult {
+	turns := append([]Turn(nil), g.turns...)
+
+	if len(turns) == 0 || turns[len(turns)-1].Guess != g.dictionary[0] {
+		var solved wordHint
+		for i := range solved {
+			solved[i] = Correct
+		}
+
+		turns = append(turns, Turn{Guess: g.dictionary[0], Hint: solved.String(), RemainingBefore: 1, RemainingAfter: 1,
+			Buckets: map[string]int{solved.String(): 1}})
+	}
+
+	confidence := 0.0
+	for _, candidate := range g.CandidateProbabilities() {
+		if candidate.Word == g.dictionary[0] {
+			confidence = candidate.Score
+		}
+	}
+
+	return GameResult{
+		Answer:       g.dictionary[0],
+		Guesses:      len(turns),
+		Turns:        turns,
+		Confidence:   confidence,
+		PuzzleNumber: g.options.PuzzleNumber,
+		MaxGuesses:   g.maxGuesses(),
+		options:      g.options,
+	}
+}
+
+// maxGuesses returns the number of guesses g must be won within. See GameOptions.MaxGuesses.
+func (g *Game) maxGuesses() int {
+	if g.options.MaxGuesses == 0 {
+		return defaultMaxGuesses
+	}
+
+	return g.options.MaxGuesses
+}
+
+// Won returns whether the answer was guessed within the allowed number of guesses.
+func (r GameResult) Won() bool {
+	return r.Guesses <= r.MaxGuesses
+}
+
+// ShareGrid returns the hints of the game as a grid of emoji squares, one row per guess, like the one shared by the
+// official game. Like the official game, guesses past the allowed number aren't included.
+func (r GameResult) ShareGrid() string {
+	var rows []string
+
+	for i, turn := range r.Turns {
+		if i == r.MaxGuesses {
+			break
+		}
+
+		var hint wordHint
+		if err := hint.fromString(turn.Hint); err != nil {
+			panic(err)
+		}
+
+		rows = append(rows, hint.emojis())
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+// ShareText returns the text shared by the official game: a header (e.g. "Wordle 1,234 4/6", with X in place of the
+// number of guesses if the game was lost) followed by a blank line and the share grid. The puzzle number is left out
+// of the header if it's zero.
+func (r GameResult) ShareText() string {
+	header := []string{"Wordle"}
+
+	if r.PuzzleNumber != 0 {
+		header = append(header, formatThousands(r.PuzzleNumber))
+	}
+
+	guesses := "X"
+	if r.Won() {
+		guesses = strconv.Itoa(r.Guesses)
+	}
+	header = append(header, fmt.Sprintf("%v/%v", guesses, r.MaxGuesses))
+
+	return strings.Join(header, " ") + "\n\n" + r.ShareGrid()
+}
+
+// shareSquares maps the squares of shared grids to the hints they show. Besides the usual squares, light mode shares
+// absent letters as white squares, and high contrast mode shares correct and present letters as orange and blue squares.
+var shareSquares = map[rune]LetterHint{
+	'⬛': Absent,
+	'⬜': Absent,
+	'🟨': Present,
+	'🟦': Present,
+	'🟩': Correct,
+	'🟧': Correct,
+}
+
+// The variation selector which can follow a square in pasted text, asking for it to be shown as an emoji.
+const emojiVariationSelector = '\ufe0f'
+
+// ParseShareCode parses the hints of each turn from text shared by the official game (see GameResult.ShareText), e.g.
+// as pasted from a message. Shares don't include the guesses, so only the hints of the turns are set. The header line
+// (e.g. "Wordle 1,234 4/6") and blank lines are ignored.
+//
+// Errors include the line number of the malformed line.
+func ParseShareCode(code string) ([]Turn, error) {
+	var turns []Turn
+
+	err := scanLines(strings.NewReader(code), func(line string) error {
+		if len(turns) == 0 && strings.HasPrefix(line, "Wordle") {
+			return nil
+		}
+
+		var hint wordHint
+		letters := 0
+		for _, r := range line {
+			if r == emojiVariationSelector {
+				continue
+			}
+
+			h, ok := shareSquares[r]
+			if !ok {
+				return fmt.Errorf("unexpected %q in grid row %q", r, line)
+			}
+
+			if letters < wordSize {
+				hint[letters] = h
+			}
+			letters++
+		}
+
+		if letters != wordSize {
+			return &WrongLengthError{Expected: wordSize, Got: letters}
+		}
+
+		turns = append(turns, Turn{Hint: hint.String()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return turns, nil
+}
+
+// formatThousands formats n with commas separating groups of thousands, e.g. 1234 as "1,234".
+func formatThousands(n int) string {
+	digits := strconv.Itoa(n)
+
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+
+	return sign + digits
+}
@@ -0,0 +1,168 @@
+package wordle
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestShareTextSolved(t *testing.T) {
+	result := GameResult{
+		Answer:  "hills",
+		Guesses: 3,
+		Turns: []Turn{
+			{Guess: "tares", Hint: "bbbbg"},
+			{Guess: "bills", Hint: "bgggg"},
+			{Guess: "hills", Hint: "ggggg"},
+		},
+		PuzzleNumber: 1234,
+		MaxGuesses:   defaultMaxGuesses,
+	}
+
+	want := "Wordle 1,234 3/6\n\n⬛⬛⬛⬛🟩\n⬛🟩🟩🟩🟩\n🟩🟩🟩🟩🟩"
+	if got := result.ShareText(); got != want {
+		t.Errorf("ShareText() = %q, want %q", got, want)
+	}
+}
+
+func TestShareTextFailed(t *testing.T) {
+	result := GameResult{
+		Answer:  "hills",
+		Guesses: 3,
+		Turns: []Turn{
+			{Guess: "fills", Hint: "bgggg"},
+			{Guess: "pills", Hint: "bgggg"},
+			{Guess: "hills", Hint: "ggggg"},
+		},
+		MaxGuesses: 2,
+	}
+
+	// Guesses past the allowed number aren't shared.
+	want := "Wordle X/2\n\n⬛🟩🟩🟩🟩\n⬛🟩🟩🟩🟩"
+	if got := result.ShareText(); got != want {
+		t.Errorf("ShareText() = %q, want %q", got, want)
+	}
+}
+
+func TestMarshalDocument(t *testing.T) {
+	result, err := Solve(GameOptions{Dictionary: Answers[:500], Answer: "moist", BlockedGuesses: map[string]bool{"sissy": true},
+		EntropyReference: OriginalFull})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := result.MarshalDocument()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Answer  string `json:"answer"`
+		Guesses int    `json:"guesses"`
+		Won     bool   `json:"won"`
+		Opener  string `json:"opener"`
+		Options struct {
+			DictionarySize   int      `json:"dictionarySize"`
+			BlockedGuesses   []string `json:"blockedGuesses"`
+			EntropyReference string   `json:"entropyReference"`
+		} `json:"options"`
+		Turns []struct {
+			Guess           string         `json:"guess"`
+			Hint            string         `json:"hint"`
+			RemainingBefore int            `json:"remainingBefore"`
+			RemainingAfter  int            `json:"remainingAfter"`
+			ActualEntropy   *float64       `json:"actualEntropy"`
+			Buckets         map[string]int `json:"buckets"`
+		} `json:"turns"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Answer != "moist" || doc.Guesses != result.Guesses || !doc.Won || doc.Opener != result.Turns[0].Guess {
+		t.Errorf("document is for %v, %v guesses, won %v, opening with %v, want %v, %v, true, %v", doc.Answer,
+			doc.Guesses, doc.Won, doc.Opener, "moist", result.Guesses, result.Turns[0].Guess)
+	}
+
+	if doc.Options.DictionarySize != 500 || fmt.Sprint(doc.Options.BlockedGuesses) != "[sissy]" ||
+		doc.Options.EntropyReference != "original" {
+		t.Errorf("document options are %+v", doc.Options)
+	}
+
+	if len(doc.Turns) != len(result.Turns) {
+		t.Fatalf("document has %v turns, want %v", len(doc.Turns), len(result.Turns))
+	}
+
+	for i, turn := range doc.Turns {
+		want := result.Turns[i]
+		if turn.Guess != want.Guess || turn.Hint != want.Hint || turn.RemainingBefore != want.RemainingBefore ||
+			turn.RemainingAfter != want.RemainingAfter {
+			t.Errorf("turn %v is %+v, want %+v", i+1, turn, want)
+		}
+
+		if turn.ActualEntropy == nil || *turn.ActualEntropy != InfoGained(want.RemainingBefore, want.RemainingAfter) {
+			t.Errorf("turn %v has actual entropy %v, want %v", i+1, turn.ActualEntropy,
+				InfoGained(want.RemainingBefore, want.RemainingAfter))
+		}
+
+		total := 0
+		for _, size := range turn.Buckets {
+			total += size
+		}
+
+		if total != turn.RemainingBefore || turn.Buckets[turn.Hint] != turn.RemainingAfter {
+			t.Errorf("turn %v buckets %v don't split up the %v possible answers", i+1, turn.Buckets, turn.RemainingBefore)
+		}
+	}
+}
+
+func TestParseShareCode(t *testing.T) {
+	code := "Wordle 1,234 4/6\n\n⬛🟨⬛⬛🟩\n⬜️🟩🟩⬛🟩\n🟧🟦⬛🟩🟩\r\n🟩🟩🟩🟩🟩\n"
+
+	turns, err := ParseShareCode(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var hints []string
+	for _, turn := range turns {
+		if turn.Guess != "" {
+			t.Errorf("turn %v has guess %v, but shares don't include them", turn, turn.Guess)
+		}
+		hints = append(hints, turn.Hint)
+	}
+
+	if got, want := fmt.Sprint(hints), "[bybbg bggbg gybgg ggggg]"; got != want {
+		t.Errorf("hints = %v, want %v", got, want)
+	}
+
+	// Sharing a game and parsing the share gives back its hints.
+	result := GameResult{Answer: "hills", Guesses: 2, MaxGuesses: 6, PuzzleNumber: 1234,
+		Turns: []Turn{{Guess: "bills", Hint: "bgggg"}, {Guess: "hills", Hint: "ggggg"}}}
+	turns, err = ParseShareCode(result.ShareText())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(turns) != 2 || turns[0].Hint != "bgggg" || turns[1].Hint != "ggggg" {
+		t.Errorf("parsed the share of %v as %v", result.Turns, turns)
+	}
+}
+
+func TestParseShareCodeErrors(t *testing.T) {
+	tests := []struct {
+		code, err string
+	}{
+		{"Wordle 1,234 4/6\n⬛🟨⬛⬛\n", "line 2: "},
+		{"⬛🟨⬛⬛🟩\n⬛🟨x⬛🟩\n", "line 2: unexpected 'x'"},
+		{"⬛🟨⬛⬛🟩\nWordle 1,234 4/6\n", "line 2: unexpected 'W'"},
+	}
+
+	for _, test := range tests {
+		_, err := ParseShareCode(test.code)
+		if err == nil || !strings.HasPrefix(err.Error(), test.err) {
+			t.Errorf("ParseShareCode(%q) error = %v, want %v...", test.code, err, test.err)
+		}
+	}
+}
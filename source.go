@@ -0,0 +1,74 @@
+package wordle
+
+import (
+	"fmt"
+	"os"
+)
+
+// A DictionarySource provides the words the answer can be, e.g. from a file, an embedded file system or a remote
+// service. See GameOptions.DictionarySource.
+type DictionarySource interface {
+	// Words returns every word in the dictionary. The result must not be modified.
+	Words() []string
+
+	// Contains returns whether word is in the dictionary.
+	Contains(word string) bool
+}
+
+// A WordList is a DictionarySource holding its words in memory.
+type WordList []string
+
+// Words returns w.
+func (w WordList) Words() []string {
+	return w
+}
+
+// Contains returns whether word is in w. It looks at every word, so it's slow for large lists.
+func (w WordList) Contains(word string) bool {
+	for _, other := range w {
+		if other == word {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dictionarySource returns the source of the dictionary options configure.
+func (options GameOptions) dictionarySource() DictionarySource {
+	switch {
+	case options.Dictionary != nil:
+		return WordList(options.Dictionary)
+	case options.DictionarySource != nil:
+		return options.DictionarySource
+	default:
+		return WordList(ValidWords)
+	}
+}
+
+// LoadDictionary loads a dictionary from the file at path, which holds one word per line, e.g. for GameOptions.Dictionary.
+// Files exported by other tools are handled: blank lines and whitespace around words are ignored, as are Windows line
+// endings and a byte order mark at the start of the file. It returns an error if a word can't be a guess or answer
+// (see checkWord), including its line number.
+func LoadDictionary(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var words []string
+	err = scanLines(file, func(line string) error {
+		if err := checkWord(line); err != nil {
+			return fmt.Errorf("bad word: %w", err)
+		}
+
+		words = append(words, line)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+
+	return words, nil
+}
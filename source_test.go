@@ -0,0 +1,101 @@
+package wordle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// A mapSource is a DictionarySource backed by a set of words, which can be looked up quickly.
+type mapSource struct {
+	words    []string
+	contains map[string]bool
+}
+
+func newMapSource(words []string) mapSource {
+	s := mapSource{words: words, contains: map[string]bool{}}
+	for _, word := range words {
+		s.contains[word] = true
+	}
+
+	return s
+}
+
+func (s mapSource) Words() []string {
+	return s.words
+}
+
+func (s mapSource) Contains(word string) bool {
+	return s.contains[word]
+}
+
+func TestDictionarySource(t *testing.T) {
+	source := newMapSource(Answers[:300])
+
+	result, err := Solve(GameOptions{Answer: "moist", DictionarySource: source})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := Solve(GameOptions{Answer: "moist", Dictionary: Answers[:300]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Guesses != want.Guesses || result.Turns[0].Guess != want.Turns[0].Guess {
+		t.Errorf("solving from a source took %v, solving from the same words %v", result.Turns, want.Turns)
+	}
+
+	if _, err := Benchmark(GameOptions{DictionarySource: source}, []string{"tares"}); err == nil {
+		t.Error("benchmarking an answer the source doesn't contain didn't return an error")
+	}
+
+	if _, err := NewGame(GameOptions{Dictionary: Answers[:300], DictionarySource: source}); err == nil {
+		t.Error("setting both Dictionary and DictionarySource didn't return an error")
+	}
+}
+
+func TestLoadDictionary(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, contents := range map[string]string{
+		"unix":         "crane\nmoist\nbills\n",
+		"crlf":         "crane\r\nmoist\r\nbills\r\n",
+		"bom":          "\ufeffcrane\nmoist\nbills",
+		"blank lines":  "\ncrane\n\n\nmoist\n \t \nbills\n\n",
+		"whitespace":   "  crane\t\n\tmoist \r\n bills  \r\n",
+		"all of these": "\ufeff crane \r\n\r\nmoist\r\n  \r\nbills\r\n",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		words, err := LoadDictionary(path)
+		if err != nil {
+			t.Errorf("%v: %v", name, err)
+			continue
+		}
+
+		if got := fmt.Sprint(words); got != "[crane moist bills]" {
+			t.Errorf("%v: loaded %q, want [crane moist bills]", name, words)
+		}
+	}
+}
+
+func TestLoadDictionaryErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words")
+	if err := os.WriteFile(path, []byte("crane\r\n\r\nab1cd\r\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadDictionary(path); err == nil || !strings.Contains(err.Error(), "line 3: bad word") {
+		t.Errorf("loading a dictionary with ab1cd returned error %v, want a bad word on line 3", err)
+	}
+
+	if _, err := LoadDictionary(path + "-missing"); err == nil {
+		t.Error("loading a missing dictionary didn't return an error")
+	}
+}
@@ -0,0 +1,75 @@
+package wordle
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// A Strategy is a way of scoring guesses, where the guess with the highest score is best.
+type Strategy int
+
+const (
+	// EntropyStrategy scores guesses by their entropy: the information they're expected to reveal on average. See
+	// hintEntropy.
+	EntropyStrategy Strategy = iota
+
+	// MinimaxStrategy scores guesses by the information they're guaranteed to reveal, i.e. in the worst case. See
+	// guaranteedInfo.
+	MinimaxStrategy
+)
+
+// BestGuess returns the best guess among allowedGuesses for narrowing down candidates, the possible answers, along with
+// its score according to strategy. If allowedGuesses is empty, only candidates can be guessed. Unlike Game.BestGuess,
+// it doesn't depend on the state of a game, so it can be used with possible answers tracked elsewhere.
+//
+// The first of the guesses tied for the best is returned. If there are no candidates, it returns an empty guess.
+func BestGuess(candidates, allowedGuesses []string, strategy Strategy) (string, float64) {
+	if len(candidates) == 0 {
+		return "", 0
+	}
+
+	if len(allowedGuesses) == 0 {
+		allowedGuesses = candidates
+	}
+
+	var score func(word string) float64
+
+	switch strategy {
+	case EntropyStrategy:
+		pool := newEntropyWorkerPool(runtime.NumCPU())
+		defer pool.close()
+
+		version := newDictionaryVersion()
+		score = func(word string) float64 {
+			return pool.calculateEntropy(word, candidates, version)
+		}
+	case MinimaxStrategy:
+		score = func(word string) float64 {
+			return guaranteedInfo(word, candidates)
+		}
+	default:
+		panic(fmt.Sprintf("unknown strategy %v", strategy))
+	}
+
+	best := ScoredGuess{Word: allowedGuesses[0], Score: score(allowedGuesses[0])}
+	for _, word := range allowedGuesses[1:] {
+		if wordScore := score(word); wordScore > best.Score {
+			best = ScoredGuess{Word: word, Score: wordScore}
+		}
+	}
+
+	return best.Word, best.Score
+}
+
+// guaranteedInfo returns the information, in bits, that guessing guess is guaranteed to reveal about which word in
+// dictionary is the answer. It's the information revealed by the hint which leaves the most possible answers.
+func guaranteedInfo(guess string, dictionary []string) float64 {
+	largestBucket := 0
+	for _, size := range bucket(guess, dictionary) {
+		if size > largestBucket {
+			largestBucket = size
+		}
+	}
+
+	return InfoGained(len(dictionary), largestBucket)
+}
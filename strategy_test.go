@@ -0,0 +1,35 @@
+package wordle
+
+import "testing"
+
+func TestBestGuessMatchesGame(t *testing.T) {
+	defer quiet()()
+
+	for _, strategy := range []Strategy{EntropyStrategy, MinimaxStrategy} {
+		g, err := NewGame(GameOptions{Dictionary: ValidWords[:500], Strategy: strategy})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := g.Apply("crane", createHint("crane", "moist").String()); err != nil {
+			t.Fatal(err)
+		}
+
+		want, wantScore := g.getBestGuess(false)
+		if strategy == MinimaxStrategy {
+			// The game reports the entropy of its guess whatever the strategy.
+			wantScore = guaranteedInfo(want, g.dictionary)
+		}
+
+		got, score := BestGuess(g.dictionary, g.dictionary, strategy)
+		if got != want || score != wantScore {
+			t.Errorf("strategy %v: best guess is %v (%v), the game's is %v (%v)", strategy, got, score, want, wantScore)
+		}
+	}
+}
+
+func TestBestGuessNoCandidates(t *testing.T) {
+	if guess, score := BestGuess(nil, ValidWords[:10], EntropyStrategy); guess != "" || score != 0 {
+		t.Errorf("best guess with no candidates is %v (%v), want none", guess, score)
+	}
+}
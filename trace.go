@@ -0,0 +1,22 @@
+package wordle
+
+import (
+	"context"
+	"runtime/trace"
+)
+
+// Names of the runtime/trace regions recorded if GameOptions.Trace is set.
+const (
+	traceFirstGuess = "wordle.firstGuess"
+	traceEntropy    = "wordle.entropy"
+)
+
+// traceRegion starts a runtime/trace region called name if the first guess is being calculated and GameOptions.Trace
+// is set. It returns a function which ends the region, which does nothing if no region was started.
+func (g *Game) traceRegion(name string) func() {
+	if !g.options.Trace || len(g.turns) != 0 {
+		return func() {}
+	}
+
+	return trace.StartRegion(context.Background(), name).End
+}
@@ -0,0 +1,58 @@
+package wordle
+
+import (
+	"bytes"
+	"runtime/trace"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	defer quiet()()
+
+	dictionary := ValidWords[700:1000]
+	key := newOpenerKey(dictionary, nil)
+
+	bestGuess := func(traced bool) ScoredGuess {
+		forgetOpener(key)
+
+		g, err := NewGame(GameOptions{Dictionary: dictionary, Trace: traced})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		guess, entropy := g.BestGuess()
+		return ScoredGuess{Word: guess, Score: entropy}
+	}
+
+	want := bestGuess(false)
+
+	// Tracing without a trace being recorded does nothing.
+	if got := bestGuess(true); got != want {
+		t.Errorf("best guess traced without a trace is %v, want %v", got, want)
+	}
+
+	// Function names can contain the region names too, so compare with a trace recorded without GameOptions.Trace.
+	record := func(traced bool) (ScoredGuess, []byte) {
+		var buf bytes.Buffer
+		if err := trace.Start(&buf); err != nil {
+			t.Fatal(err)
+		}
+		best := bestGuess(traced)
+		trace.Stop()
+
+		return best, buf.Bytes()
+	}
+
+	_, untraced := record(false)
+	got, traced := record(true)
+
+	if got != want {
+		t.Errorf("best guess traced is %v, want %v", got, want)
+	}
+
+	for _, region := range []string{traceFirstGuess, traceEntropy} {
+		if bytes.Count(traced, []byte(region)) <= bytes.Count(untraced, []byte(region)) {
+			t.Errorf("trace doesn't contain the %v region", region)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package wordle
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A Turn is a single guess made during a game, along with the hint it resulted in.
+type Turn struct {
+	Guess string
+
+	// Hint uses the same format as hints typed during a game: b (black) for absent letters, y (yellow) for present
+	// letters and g (green) for correct letters.
+	Hint string
+
+	// RemainingBefore and RemainingAfter are the number of possible answers before and after the guess, and Entropy is
+	// the expected entropy of the guess when it was made. They're only known for turns played in a Game, and are zero
+	// for turns parsed from a transcript.
+	RemainingBefore, RemainingAfter int
+	Entropy                         float64
+
+	// Gap is how much more entropy the best guess had than the runner-up when the guess was made. A small gap means the
+	// choice barely mattered, a large one that the best guess was clearly best. Like the fields above, it's only known
+	// for turns played in a Game, and only if every candidate was scored that turn (e.g. not for the cached first
+	// guess); otherwise it's zero.
+	Gap float64
+
+	// TopGuesses holds the best guesses when the guess was made, best first, and Buckets the number of possible answers
+	// which would have resulted in each hint (e.g. "bygbb"). Like the fields above, they're only known for turns played
+	// in a Game, and TopGuesses only if every candidate was scored that turn.
+	TopGuesses []ScoredGuess
+	Buckets    map[string]int
+}
+
+// CorrectMask returns which letters of the guess were correct, e.g. to highlight the solved positions. A malformed
+// hint has none.
+func (t Turn) CorrectMask() [wordSize]bool {
+	return t.mask(Correct)
+}
+
+// PresentMask returns which letters of the guess were present. A malformed hint has none.
+func (t Turn) PresentMask() [wordSize]bool {
+	return t.mask(Present)
+}
+
+// AbsentMask returns which letters of the guess were absent. A malformed hint has none.
+func (t Turn) AbsentMask() [wordSize]bool {
+	return t.mask(Absent)
+}
+
+// mask returns which letters of the guess had the letter hint h.
+func (t Turn) mask(h LetterHint) [wordSize]bool {
+	var hint wordHint
+	if hint.fromString(t.Hint) != nil {
+		return [wordSize]bool{}
+	}
+
+	return hint.mask(h)
+}
+
+// ParseTranscript parses the turns of a game from r. Each line holds a turn: the guess and the hint, separated by
+// whitespace (e.g. "tares bygbb"). Blank lines are ignored.
+//
+// Errors include the line number of the malformed line.
+func ParseTranscript(r io.Reader) ([]Turn, error) {
+	var turns []Turn
+
+	err := scanLines(r, func(line string) error {
+		turn, err := parseTurn(line)
+		turns = append(turns, turn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return turns, nil
+}
+
+// WriteTranscript writes turns to w in the format ParseTranscript reads, one "guess hint" line per turn.
+func WriteTranscript(w io.Writer, turns []Turn) error {
+	for _, turn := range turns {
+		if _, err := fmt.Fprintf(w, "%v %v\n", turn.Guess, turn.Hint); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// The UTF-8 byte order mark, which some editors put at the start of text files.
+const byteOrderMark = "\ufeff"
+
+// scanLines calls parse with each line of r which isn't blank, stopping at the first error. Lines are trimmed of
+// surrounding whitespace, including the carriage returns of Windows line endings, and a byte order mark at the start of
+// r is ignored. Errors include the line number of the malformed line.
+func scanLines(r io.Reader, parse func(line string) error) error {
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if lineNum == 1 {
+			line = strings.TrimPrefix(line, byteOrderMark)
+		}
+
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		if err := parse(line); err != nil {
+			return fmt.Errorf("line %v: %w", lineNum, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// parseTurn parses a single line of a transcript.
+func parseTurn(line string) (Turn, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 2 {
+		return Turn{}, fmt.Errorf("expected a guess and a hint, got %q", line)
+	}
+
+	guess, hint := fields[0], fields[1]
+
+	if err := checkWord(guess); err != nil {
+		return Turn{}, fmt.Errorf("bad guess: %w", err)
+	}
+
+	var h wordHint
+	if err := h.fromString(hint); err != nil {
+		return Turn{}, fmt.Errorf("bad hint: %w", err)
+	}
+
+	return Turn{Guess: guess, Hint: hint}, nil
+}
+
+// ValidateTranscript returns an error if turns couldn't have been played in a finished game whose answer is answer: if
+// a guess or hint is malformed, if a hint isn't the one guessing its word would reveal, if there are turns after the
+// answer was guessed, or if the answer was never guessed. Errors include the number of the first inconsistent turn,
+// counting from 1.
+//
+// Since every hint matches the answer, the hints of a valid transcript always leave the answer possible.
+func ValidateTranscript(turns []Turn, answer string) error {
+	if err := checkWord(answer); err != nil {
+		return fmt.Errorf("bad answer: %w", err)
+	}
+
+	for i, turn := range turns {
+		if _, err := parseTurn(turn.Guess + " " + turn.Hint); err != nil {
+			return fmt.Errorf("turn %v: %w", i+1, err)
+		}
+
+		expected := createHint(turn.Guess, answer)
+		if turn.Hint != expected.String() {
+			return fmt.Errorf("turn %v: guessing %v with answer %v gives hint %v, not %v", i+1, turn.Guess, answer, expected, turn.Hint)
+		}
+
+		if expected.solved() && i != len(turns)-1 {
+			return fmt.Errorf("turn %v: the answer was already guessed, but the game continued", i+2)
+		}
+	}
+
+	if len(turns) == 0 {
+		return fmt.Errorf("no turns: the answer %v was never guessed", answer)
+	}
+
+	if turns[len(turns)-1].Guess != answer {
+		return fmt.Errorf("turn %v: the game ended without guessing the answer %v", len(turns), answer)
+	}
+
+	return nil
+}
+
+// IsHardModeGame returns whether turns follow the rules of hard mode: every guess keeps the correct (green) letters
+// revealed by the guesses before it in place, and uses their present (yellow) letters somewhere. If not, it also returns
+// the index in turns of the first guess which doesn't; otherwise the index is -1. Turns with a malformed guess or hint
+// (e.g. the wrong size) can't have been played, so if there are any, it returns false and the index of the first.
+func IsHardModeGame(turns []Turn) (bool, int) {
+	hints := make([]wordHint, len(turns))
+	for i, turn := range turns {
+		if checkWord(turn.Guess) != nil || hints[i].fromString(turn.Hint) != nil {
+			return false, i
+		}
+	}
+
+	revealed := newKnowledge()
+
+	for i, turn := range turns {
+		if !revealed.allows(turn.Guess) {
+			return false, i
+		}
+
+		revealed.addRevealed(turn.Guess, hints[i])
+	}
+
+	return true, -1
+}
+
+// A MultiTurn is a single guess made during a game with several boards (e.g. Quordle), along with the hint it resulted
+// in on each board. See MultiGame.
+type MultiTurn struct {
+	Guess string
+
+	// Hints holds the hint of each board, in the same format as Turn.Hint. The hint of a board which was already solved
+	// is SolvedPlaceholder.
+	Hints []string
+}
+
+// SolvedPlaceholder is used instead of a hint for boards which were solved by an earlier guess.
+const SolvedPlaceholder = "-"
+
+// ParseMultiTranscript parses the turns of a game with the given number of boards from r. Each line holds a turn: the
+// guess and the hint of each board, separated by whitespace (e.g. "tares bygbb bbbbb - gybbb"). Boards which were
+// already solved have SolvedPlaceholder instead of a hint. Blank lines are ignored.
+//
+// Errors include the line number of the malformed line.
+func ParseMultiTranscript(r io.Reader, boards int) ([]MultiTurn, error) {
+	var turns []MultiTurn
+
+	err := scanLines(r, func(line string) error {
+		fields := strings.Fields(line)
+		if len(fields) != boards+1 {
+			return fmt.Errorf("expected a guess and %v hints, got %q", boards, line)
+		}
+
+		if err := checkWord(fields[0]); err != nil {
+			return fmt.Errorf("bad guess: %w", err)
+		}
+
+		for i, hint := range fields[1:] {
+			if hint == SolvedPlaceholder {
+				continue
+			}
+
+			var h wordHint
+			if err := h.fromString(hint); err != nil {
+				return fmt.Errorf("bad hint for board %v: %w", i+1, err)
+			}
+		}
+
+		turns = append(turns, MultiTurn{Guess: fields[0], Hints: fields[1:]})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return turns, nil
+}
@@ -0,0 +1,260 @@
+package wordle
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTurnMasks(t *testing.T) {
+	turn := Turn{Guess: "tares", Hint: "gyb?g"}
+
+	if got, want := turn.CorrectMask(), [wordSize]bool{true, false, false, false, true}; got != want {
+		t.Errorf("CorrectMask() = %v, want %v", got, want)
+	}
+
+	if got, want := turn.PresentMask(), [wordSize]bool{false, true, false, false, false}; got != want {
+		t.Errorf("PresentMask() = %v, want %v", got, want)
+	}
+
+	if got, want := turn.AbsentMask(), [wordSize]bool{false, false, true, false, false}; got != want {
+		t.Errorf("AbsentMask() = %v, want %v", got, want)
+	}
+
+	if got := (Turn{Guess: "tares", Hint: "gyb"}).CorrectMask(); got != [wordSize]bool{} {
+		t.Errorf("CorrectMask() of a malformed hint = %v, want none", got)
+	}
+}
+
+func TestParseTranscript(t *testing.T) {
+	turns, err := ParseTranscript(strings.NewReader("tares bygbb\n\n  crane  ggbbg \r\nhills ggggg\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Turn{{Guess: "tares", Hint: "bygbb"}, {Guess: "crane", Hint: "ggbbg"}, {Guess: "hills", Hint: "ggggg"}}
+	if len(turns) != len(want) {
+		t.Fatalf("parsed %v turns, want %v", turns, want)
+	}
+
+	for i := range want {
+		if turns[i].Guess != want[i].Guess || turns[i].Hint != want[i].Hint {
+			t.Errorf("turn %v = %v %v, want %v %v", i+1, turns[i].Guess, turns[i].Hint, want[i].Guess, want[i].Hint)
+		}
+	}
+}
+
+func TestParseTranscriptErrors(t *testing.T) {
+	tests := []struct {
+		transcript, err string
+	}{
+		{"tares bygbb\ntares\n", "line 2: expected a guess and a hint"},
+		{"tares bygbb\n\ntar bygbb\n", "line 3: bad guess"},
+		{"tares bygbx\n", "line 1: bad hint"},
+		{"tares bygbb extra\n", "line 1: expected a guess and a hint"},
+	}
+
+	for _, test := range tests {
+		_, err := ParseTranscript(strings.NewReader(test.transcript))
+		if err == nil || !strings.HasPrefix(err.Error(), test.err) {
+			t.Errorf("ParseTranscript(%q) error = %v, want %v...", test.transcript, err, test.err)
+		}
+	}
+}
+
+func TestParseMultiTranscript(t *testing.T) {
+	turns, err := ParseMultiTranscript(strings.NewReader("tares bygbb bbbbb gybbb ggggg\n\ncrane ggbbg byybb bbgbb -\n"), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []MultiTurn{
+		{Guess: "tares", Hints: []string{"bygbb", "bbbbb", "gybbb", "ggggg"}},
+		{Guess: "crane", Hints: []string{"ggbbg", "byybb", "bbgbb", SolvedPlaceholder}},
+	}
+	if len(turns) != len(want) {
+		t.Fatalf("parsed %v turns, want %v", turns, want)
+	}
+
+	for i := range want {
+		if turns[i].Guess != want[i].Guess || strings.Join(turns[i].Hints, " ") != strings.Join(want[i].Hints, " ") {
+			t.Errorf("turn %v = %v %v, want %v %v", i+1, turns[i].Guess, turns[i].Hints, want[i].Guess, want[i].Hints)
+		}
+	}
+}
+
+func TestParseMultiTranscriptErrors(t *testing.T) {
+	tests := []struct {
+		transcript, err string
+	}{
+		{"tares bygbb bbbbb\n", "line 1: expected a guess and 4 hints"},
+		{"tares bygbb bbbbb gybbb ggggg\ntar bygbb bbbbb gybbb ggggg\n", "line 2: bad guess"},
+		{"tares bygbb bbbbb gybbx ggggg\n", "line 1: bad hint for board 3"},
+	}
+
+	for _, test := range tests {
+		_, err := ParseMultiTranscript(strings.NewReader(test.transcript), 4)
+		if err == nil || !strings.HasPrefix(err.Error(), test.err) {
+			t.Errorf("ParseMultiTranscript(%q) error = %v, want %v...", test.transcript, err, test.err)
+		}
+	}
+}
+
+func TestValidateTranscript(t *testing.T) {
+	result, err := Solve(GameOptions{Dictionary: Answers[:500], Answer: "moist"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ValidateTranscript(result.Turns, "moist"); err != nil {
+		t.Errorf("solved game is invalid: %v", err)
+	}
+
+	valid := []Turn{{Guess: "tares", Hint: createHint("tares", "moist").String()}, {Guess: "moist", Hint: "ggggg"}}
+
+	tests := []struct {
+		turns []Turn
+		err   string
+	}{
+		{[]Turn{valid[0], {Guess: "colon", Hint: "bbbbb"}}, "turn 2: guessing colon with answer moist gives hint"},
+		{[]Turn{{Guess: "tares", Hint: "bbbbx"}}, "turn 1: bad hint"},
+		{[]Turn{valid[0], valid[1], valid[0]}, "turn 3: the answer was already guessed"},
+		{valid[:1], "turn 1: the game ended without guessing the answer moist"},
+		{nil, "no turns: the answer moist was never guessed"},
+	}
+
+	for _, test := range tests {
+		err := ValidateTranscript(test.turns, "moist")
+		if err == nil || !strings.HasPrefix(err.Error(), test.err) {
+			t.Errorf("ValidateTranscript(%v) error = %v, want %v...", test.turns, err, test.err)
+		}
+	}
+
+	if err := ValidateTranscript([]Turn{{Guess: "tares", Hint: "bbbbb"}}, "pilou"); err == nil {
+		t.Error("an unfinished game with consistent hints is valid")
+	}
+
+	if err := ValidateTranscript(valid, "moi"); err == nil {
+		t.Error("a malformed answer didn't return an error")
+	}
+}
+
+func TestIsHardModeGame(t *testing.T) {
+	// Every guess keeps the greens in place and uses the yellows.
+	compliant := []Turn{
+		{Guess: "tares", Hint: createHint("tares", "moist").String()},
+		{Guess: "spilt", Hint: createHint("spilt", "moist").String()},
+		{Guess: "moist", Hint: "ggggg"},
+	}
+
+	if ok, turn := IsHardModeGame(compliant); !ok || turn != -1 {
+		t.Errorf("IsHardModeGame(%v) = %v, %v, want true, -1", compliant, ok, turn)
+	}
+
+	// dough doesn't use the s and i revealed by spilt.
+	violating := []Turn{compliant[0], compliant[1], {Guess: "dough", Hint: createHint("dough", "moist").String()},
+		compliant[2]}
+
+	if ok, turn := IsHardModeGame(violating); ok || turn != 2 {
+		t.Errorf("IsHardModeGame(%v) = %v, %v, want false, 2", violating, ok, turn)
+	}
+
+	// A green letter moved.
+	moved := []Turn{{Guess: "crane", Hint: "ggbbb"}, {Guess: "cxrne", Hint: "gbbbb"}}
+	if ok, turn := IsHardModeGame(moved); ok || turn != 1 {
+		t.Errorf("IsHardModeGame(%v) = %v, %v, want false, 1", moved, ok, turn)
+	}
+
+	malformed := []struct {
+		turns []Turn
+		want  int
+	}{
+		{[]Turn{compliant[0], {Guess: "moi", Hint: "ggg"}}, 1},
+		{[]Turn{{Guess: "tares", Hint: "bbbb"}, compliant[2]}, 0},
+		{[]Turn{compliant[0], compliant[1], {Guess: "moistt", Hint: "gggggg"}}, 2},
+		{[]Turn{{Guess: "", Hint: ""}}, 0},
+	}
+
+	for _, test := range malformed {
+		if ok, turn := IsHardModeGame(test.turns); ok || turn != test.want {
+			t.Errorf("IsHardModeGame(%v) = %v, %v, want false, %v", test.turns, ok, turn, test.want)
+		}
+	}
+}
+
+func TestResumeFromTranscript(t *testing.T) {
+	defer quiet()()
+
+	dictionary := Answers
+	answer := "moist"
+	guesses := []string{"cigar", "humph"}
+
+	// Play part of a game, saving each turn, until the input runs out.
+	interrupted, err := NewGame(GameOptions{Dictionary: dictionary})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var saved bytes.Buffer
+	interrupted.options.OnTurn = func(turn Turn) {
+		if err := WriteTranscript(&saved, []Turn{turn}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var input strings.Builder
+	for _, guess := range guesses {
+		input.WriteString(guess + "\n" + createHint(guess, answer).String() + "\n")
+	}
+
+	previous := stdin
+	stdin = bufio.NewReader(strings.NewReader(input.String()))
+	defer func() {
+		stdin = previous
+	}()
+
+	if _, err := interrupted.PlaySafe(); err == nil {
+		t.Fatal("PlaySafe() didn't stop when the input ran out")
+	}
+
+	turns, err := ParseTranscript(&saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(turns) != len(guesses) {
+		t.Fatalf("saved %v turns, want %v", len(turns), len(guesses))
+	}
+
+	resumed, err := NewGame(GameOptions{Dictionary: dictionary})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := resumed.Replay(turns); err != nil {
+		t.Fatal(err)
+	}
+
+	// The same turns applied to a game which was never interrupted.
+	want, err := NewGame(GameOptions{Dictionary: dictionary})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, guess := range guesses {
+		if _, _, err := want.Apply(guess, createHint(guess, answer).String()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got, want := strings.Join(resumed.dictionary, " "), strings.Join(want.dictionary, " "); got != want {
+		t.Errorf("resumed game has the possible answers %v, want %v", got, want)
+	}
+
+	gotGuess, gotEntropy := resumed.BestGuess()
+	wantGuess, wantEntropy := want.BestGuess()
+	if gotGuess != wantGuess || gotEntropy != wantEntropy {
+		t.Errorf("resumed game's best guess is %v (%v), want %v (%v)", gotGuess, gotEntropy, wantGuess, wantEntropy)
+	}
+}
@@ -0,0 +1,304 @@
+package wordle
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// A DecisionNode is a guess in a decision tree, which shows the guesses the solver makes for each hint it could get.
+type DecisionNode struct {
+	Guess   string
+	Entropy float64
+
+	// Remaining is the number of possible answers left when the guess is made.
+	Remaining int
+
+	// Children holds the next guess for each hint the guess can yield, keyed by the hint (e.g. "bygbb"). There's no
+	// child for the all correct hint, since the game is won. Nodes at the maximum depth of the tree have no children.
+	Children map[string]*DecisionNode
+}
+
+// BuildDecisionTree builds the decision tree of the solver for a game with the given options, down to depth guesses.
+// Each node is the guess the solver would make given the hints leading to it. The answer in the options is ignored,
+// since every possible answer is explored, and so are the ones which observe a game being played (e.g. OnBestUpdate,
+// OnTurn and Metrics), since no game is played.
+//
+// Every level of the tree needs the best guess for every hint of the level above it, so it takes a long time to build
+// for large dictionaries.
+func BuildDecisionTree(opts GameOptions, depth int) (*DecisionNode, error) {
+	opts = opts.withoutObservers()
+	opts.Answer = ""
+
+	g, err := NewGame(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.buildDecisionTree(depth), nil
+}
+
+// withoutObservers returns o without the options which observe a game being played, for exploring games which aren't
+// actually played.
+func (o GameOptions) withoutObservers() GameOptions {
+	o.OnBestUpdate, o.OnProgress, o.OnTurn, o.OnAnswer = nil, nil, nil, nil
+	o.Metrics, o.DumpDir = nil, ""
+
+	return o
+}
+
+// buildDecisionTree builds the decision tree for g, down to depth guesses.
+func (g *Game) buildDecisionTree(depth int) *DecisionNode {
+	if depth <= 0 {
+		return nil
+	}
+
+	guess, entropy := g.bestGuess(len(g.turns) == 0, false)
+	if len(g.dictionary) == 1 {
+		guess, entropy = g.dictionary[0], 0
+	}
+
+	node := &DecisionNode{
+		Guess:     guess,
+		Entropy:   entropy,
+		Remaining: len(g.dictionary),
+		Children:  map[string]*DecisionNode{},
+	}
+
+	if depth == 1 {
+		return node
+	}
+
+	for hint := range bucket(guess, g.dictionary) {
+		if hint.solved() {
+			continue
+		}
+
+		next := g.Clone()
+		next.apply(guess, hint)
+		node.Children[hint.String()] = next.buildDecisionTree(depth - 1)
+	}
+
+	return node
+}
+
+// DOT renders the tree rooted at n in the Graphviz DOT format. Each guess is a node, labelled with the guess and the
+// number of possible answers left when it's made, and each edge is labelled with the hint leading to the next guess.
+func (n *DecisionNode) DOT() string {
+	var b strings.Builder
+
+	b.WriteString("digraph decisions {\n")
+
+	ids := 0
+	var write func(node *DecisionNode) string
+	write = func(node *DecisionNode) string {
+		id := fmt.Sprintf("n%v", ids)
+		ids++
+
+		fmt.Fprintf(&b, "\t%v [label=\"%v\\n%v left\"];\n", id, node.Guess, node.Remaining)
+
+		hints := make([]string, 0, len(node.Children))
+		for hint := range node.Children {
+			hints = append(hints, hint)
+		}
+		sort.Strings(hints)
+
+		for _, hint := range hints {
+			child := node.Children[hint]
+			if child == nil {
+				continue
+			}
+
+			childID := write(child)
+			fmt.Fprintf(&b, "\t%v -> %v [label=\"%v\"];\n", id, childID, hint)
+		}
+
+		return id
+	}
+
+	if n != nil {
+		write(n)
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// The most guesses GuaranteedGuesses searches for a guaranteed solution within.
+const maxGuaranteedGuesses = 6
+
+// GuaranteedGuesses returns the fewest guesses needed to guarantee guessing the answer from this stage of the game,
+// including guessing the answer itself, however the hints turn out. In other words, the depth of the shallowest
+// decision tree for the possible answers. Like the solver, only hard-mode guesses are considered. If more than
+// maxGuaranteedGuesses guesses are needed, it returns -1.
+//
+// This searches every guess for every hint, so it's only feasible for small numbers of possible answers. Positions
+// reached by different guesses are only searched once.
+func (g *Game) GuaranteedGuesses() int {
+	memo := map[string]bool{}
+
+	for guesses := 1; guesses <= maxGuaranteedGuesses; guesses++ {
+		if solvableWithin(g.dictionary, g.guessOnly, guesses, memo) {
+			return guesses
+		}
+	}
+
+	return -1
+}
+
+// The most possible answers and guesses ExpectedGuesses simulates playing out. Beyond them, it estimates instead.
+const (
+	maxExpectedGuessesAnswers = 100
+	maxExpectedGuessesDepth   = maxGuaranteedGuesses
+)
+
+// The information the solver's guesses typically reveal, in bits. Used to estimate the guesses left when there are too
+// many possible answers to play them out. It's lower than the entropy of the best guesses, since later guesses reveal
+// less as the possible answers dwindle.
+const estimatedInfoPerGuess = 4.5
+
+// ExpectedGuesses returns the number of guesses the solver is expected to need from this stage of the game, including
+// guessing the answer itself, e.g. to show "about 2.3 guesses to go". Each possible answer is as likely as
+// Game.CandidateProbabilities says.
+//
+// For up to maxExpectedGuessesAnswers possible answers, it plays out the solver's best guess for every hint it could
+// result in, maxExpectedGuessesDepth guesses deep, so it's the exact average of solving each possible answer. Positions
+// reached more than once are only played out once. Beyond that, it's a rough estimate based on
+// estimatedInfoPerGuess.
+func (g *Game) ExpectedGuesses() float64 {
+	if len(g.dictionary) > maxExpectedGuessesAnswers {
+		return estimateGuesses(len(g.dictionary))
+	}
+
+	// Playing out guesses shouldn't be mistaken for calculating the best guess of this game.
+	clone := g.Clone()
+	clone.options, clone.progress = clone.options.withoutObservers(), nil
+
+	return clone.expectedGuesses(0, map[string]float64{})
+}
+
+// expectedGuesses returns ExpectedGuesses for g, which is depth guesses into playing out the solver's guesses. memo
+// holds the results calculated so far.
+func (g *Game) expectedGuesses(depth int, memo map[string]float64) float64 {
+	if len(g.dictionary) == 1 {
+		return 1
+	}
+
+	if depth == maxExpectedGuessesDepth {
+		return estimateGuesses(len(g.dictionary))
+	}
+
+	key := strings.Join(g.dictionary, ",") + "|" + strings.Join(g.guessOnly, ",")
+	if result, ok := memo[key]; ok {
+		return result
+	}
+
+	guess, _ := g.bestGuess(len(g.turns) == 0, false)
+
+	probabilities := map[wordHint]float64{}
+	for _, candidate := range g.CandidateProbabilities() {
+		probabilities[createHint(guess, candidate.Word)] += candidate.Score
+	}
+
+	result := 1.0
+	for hint, probability := range probabilities {
+		if hint.solved() {
+			continue
+		}
+
+		next := g.Clone()
+		next.apply(guess, hint)
+		result += probability * next.expectedGuesses(depth+1, memo)
+	}
+
+	memo[key] = result
+	return result
+}
+
+// estimateGuesses roughly estimates the number of guesses the solver needs for the given number of possible answers,
+// including guessing the answer itself. See estimatedInfoPerGuess.
+func estimateGuesses(answers int) float64 {
+	return 1 + math.Log2(float64(answers))/estimatedInfoPerGuess
+}
+
+// The most possible answers Game.needsLuck searches, since searching more each turn takes too long.
+const maxLuckCheckAnswers = 50
+
+// needsLuck returns whether no guesses can guarantee winning within the guesses left (see GameOptions.MaxGuesses), so
+// winning depends on how the hints turn out. It's equivalent to Game.GuaranteedGuesses returning more than the guesses
+// left, but only searches as deep as needed. It returns false if it can't tell: if more than maxLuckCheckAnswers
+// possible answers or more than maxGuaranteedGuesses guesses are left, or if no guesses are left at all.
+func (g *Game) needsLuck() bool {
+	guessesLeft := g.maxGuesses() - len(g.turns)
+
+	if len(g.dictionary) > maxLuckCheckAnswers || guessesLeft > maxGuaranteedGuesses || guessesLeft <= 0 {
+		return false
+	}
+
+	return !solvableWithin(g.dictionary, g.guessOnly, guessesLeft, map[string]bool{})
+}
+
+// solvableWithin returns whether the answer, one of dictionary, can be guaranteed to be guessed within the given number
+// of guesses, using hard-mode guesses (dictionary and guessOnly). memo holds the results calculated so far.
+func solvableWithin(dictionary, guessOnly []string, guesses int, memo map[string]bool) bool {
+	if len(dictionary) == 1 {
+		return guesses >= 1
+	}
+
+	// Guessing a word can only win if it's the answer, which can't be guaranteed when there's more than one.
+	if guesses <= 1 {
+		return false
+	}
+
+	key := fmt.Sprintf("%v|%v|%v", guesses, strings.Join(dictionary, ","), strings.Join(guessOnly, ","))
+	if result, ok := memo[key]; ok {
+		return result
+	}
+
+	result := false
+	for _, words := range [][]string{dictionary, guessOnly} {
+		for _, guess := range words {
+			if guaranteesWithin(guess, dictionary, guessOnly, guesses, memo) {
+				result = true
+				break
+			}
+		}
+
+		if result {
+			break
+		}
+	}
+
+	memo[key] = result
+	return result
+}
+
+// guaranteesWithin returns whether guessing guess next guarantees guessing the answer within the given number of
+// guesses, including guess itself. See solvableWithin.
+func guaranteesWithin(guess string, dictionary, guessOnly []string, guesses int, memo map[string]bool) bool {
+	groups := map[wordHint][]string{}
+	for _, word := range dictionary {
+		hint := createHint(guess, word)
+		groups[hint] = append(groups[hint], word)
+	}
+
+	for hint, group := range groups {
+		if hint.solved() {
+			continue
+		}
+
+		// The guess doesn't narrow down the possible answers at all, so it's a wasted guess.
+		if len(group) == len(dictionary) {
+			return false
+		}
+
+		c := Constraint{hint: hint, word: guess}
+		if !solvableWithin(group, c.Filter(guessOnly), guesses-1, memo) {
+			return false
+		}
+	}
+
+	return true
+}
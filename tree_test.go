@@ -0,0 +1,287 @@
+package wordle
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestBuildDecisionTree(t *testing.T) {
+	defer quiet()()
+
+	dictionary := ValidWords[:200]
+
+	root, err := BuildDecisionTree(GameOptions{Dictionary: dictionary}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := NewGame(GameOptions{Dictionary: dictionary})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if guess, _ := g.BestGuess(); root.Guess != guess || root.Remaining != len(dictionary) {
+		t.Errorf("root is %v with %v left, want %v with %v", root.Guess, root.Remaining, guess, len(dictionary))
+	}
+
+	// There's a child for every hint but the all correct one.
+	buckets := bucket(root.Guess, dictionary)
+	children := len(buckets)
+	if _, ok := buckets[createHint(root.Guess, root.Guess)]; ok {
+		children--
+	}
+
+	if len(root.Children) != children {
+		t.Errorf("root has %v children, want %v", len(root.Children), children)
+	}
+
+	for hint, size := range buckets {
+		if hint.solved() {
+			continue
+		}
+
+		child, ok := root.Children[hint.String()]
+		if !ok {
+			t.Errorf("no child for hint %v", hint)
+			continue
+		}
+
+		if child.Remaining != size {
+			t.Errorf("child for hint %v has %v left, want %v", hint, child.Remaining, size)
+		}
+
+		if len(child.Children) != 0 {
+			t.Errorf("child for hint %v at the maximum depth has children", hint)
+		}
+	}
+}
+
+func TestDecisionTreeMatchesComputedPlay(t *testing.T) {
+	dictionary := ValidWords[:300]
+
+	tree, err := BuildDecisionTree(GameOptions{Dictionary: dictionary}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, answer := range dictionary[:50] {
+		computed, err := Solve(GameOptions{Dictionary: dictionary, Answer: answer})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		guided, err := Solve(GameOptions{Dictionary: dictionary, Answer: answer, DecisionTree: tree})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(guided.Turns) != len(computed.Turns) {
+			t.Fatalf("%v: guided by the tree took %v guesses, computed %v", answer, guided.Guesses, computed.Guesses)
+		}
+
+		for i := range computed.Turns {
+			if guided.Turns[i].Guess != computed.Turns[i].Guess {
+				t.Errorf("%v: guess %v guided by the tree is %v, computed %v", answer, i+1, guided.Turns[i].Guess,
+					computed.Turns[i].Guess)
+			}
+		}
+	}
+}
+
+func TestBuildDecisionTreeDoesNotObserve(t *testing.T) {
+	called := false
+	options := GameOptions{
+		Dictionary:   ValidWords[:100],
+		OnBestUpdate: func(ScoredGuess, float64) { called = true },
+		OnProgress:   func(float64) { called = true },
+		OnTurn:       func(Turn) { called = true },
+		OnAnswer:     func(string) { called = true },
+		Metrics:      metricsFunc(func(string, float64) { called = true }),
+		DumpDir:      t.TempDir() + "/dump",
+	}
+
+	if _, err := BuildDecisionTree(options, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if called {
+		t.Error("building the tree called an observer of the game")
+	}
+}
+
+func TestDecisionTreeDOT(t *testing.T) {
+	root, err := BuildDecisionTree(GameOptions{Dictionary: ValidWords[:100]}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dot := root.DOT()
+	if !strings.HasPrefix(dot, "digraph decisions {\n") || !strings.HasSuffix(dot, "}\n") {
+		t.Fatalf("DOT isn't a digraph:\n%v", dot)
+	}
+
+	if !strings.Contains(dot, "n0 [label=\""+root.Guess+"\\n") {
+		t.Errorf("DOT doesn't start with the opener %v:\n%v", root.Guess, dot)
+	}
+
+	nodePattern := regexp.MustCompile(`^\t(n\d+) \[label="[a-z]{5}\\n\d+ left"\];$`)
+	edgePattern := regexp.MustCompile(`^\t(n\d+) -> (n\d+) \[label="[byg]{5}"\];$`)
+
+	nodes := map[string]bool{}
+	edges := 0
+	for _, line := range strings.Split(strings.TrimSuffix(dot, "}\n"), "\n")[1:] {
+		if line == "" {
+			continue
+		}
+
+		if match := nodePattern.FindStringSubmatch(line); match != nil {
+			nodes[match[1]] = true
+			continue
+		}
+
+		match := edgePattern.FindStringSubmatch(line)
+		if match == nil {
+			t.Errorf("malformed line %q", line)
+			continue
+		}
+
+		if !nodes[match[1]] || !nodes[match[2]] {
+			t.Errorf("edge %q is between nodes which aren't declared", line)
+		}
+		edges++
+	}
+
+	if edges != len(nodes)-1 || edges != len(root.Children) {
+		t.Errorf("DOT has %v nodes and %v edges, want a tree with %v edges", len(nodes), edges, len(root.Children))
+	}
+}
+
+func TestGuaranteedGuesses(t *testing.T) {
+	tests := []struct {
+		dictionary []string
+		want       int
+	}{
+		{[]string{"moist"}, 1},
+		{[]string{"crane", "moist"}, 2},
+		// Only the word guessed can be told apart from the others.
+		{[]string{"bills", "fills", "hills"}, 3},
+		{[]string{"bills", "fills", "hills", "kills", "mills", "pills"}, 6},
+		{[]string{"bills", "fills", "hills", "kills", "mills", "pills", "sills", "tills", "wills"}, -1},
+		// Guessing any of the _ills words tells moist apart, but not the other two.
+		{[]string{"bills", "fills", "hills", "moist"}, 3},
+		// Guessing brave tells every word apart.
+		{[]string{"crane", "brave", "grave", "moist"}, 2},
+	}
+
+	for _, test := range tests {
+		g, err := NewGame(GameOptions{Dictionary: test.dictionary})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := g.GuaranteedGuesses(); got != test.want {
+			t.Errorf("GuaranteedGuesses() for %v = %v, want %v", test.dictionary, got, test.want)
+		}
+	}
+}
+
+func TestNeedsLuck(t *testing.T) {
+	defer quiet()()
+
+	// Only the word guessed can be told apart from the others, so each guess rules out one word at most.
+	ills := []string{"bills", "fills", "hills", "kills", "mills", "pills", "sills", "tills", "wills"}
+
+	tests := []struct {
+		dictionary []string
+		maxGuesses int
+		want       bool
+	}{
+		{ills, 6, true},
+		{ills[:6], 6, false},
+		{ills[:6], 5, true},
+		{[]string{"crane", "moist"}, 2, false},
+		{[]string{"crane", "moist"}, 1, true},
+	}
+
+	for _, test := range tests {
+		g, err := NewGame(GameOptions{Dictionary: test.dictionary, MaxGuesses: test.maxGuesses})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := g.needsLuck(); got != test.want {
+			t.Errorf("needsLuck() for %v with %v guesses = %v, want %v", test.dictionary, test.maxGuesses, got, test.want)
+		}
+	}
+
+	Verbose = true
+	for _, test := range tests[:2] {
+		g, err := NewGame(GameOptions{Dictionary: test.dictionary, MaxGuesses: test.maxGuesses, Answer: "fills"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		output := captureOutput(t, func() {
+			g.Play()
+		})
+
+		if warned := strings.Contains(output, defaultMessages.NeedsLuck); warned != test.want {
+			t.Errorf("playing %v with %v guesses warned that luck is needed: %v, want %v", test.dictionary,
+				test.maxGuesses, warned, test.want)
+		}
+	}
+}
+
+func TestExpectedGuesses(t *testing.T) {
+	weights := map[string]float64{"skill": 5, "moist": 3}
+
+	for _, w := range []map[string]float64{nil, weights} {
+		g, err := NewGame(GameOptions{Dictionary: ValidWords[:300], Weights: w})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := g.Apply("crane", "bbbbb"); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(g.dictionary) > maxExpectedGuessesAnswers {
+			t.Fatalf("%v possible answers are left, too many to play out", len(g.dictionary))
+		}
+
+		// Solve each possible answer from here, and average the guesses needed.
+		var total, want float64
+		for _, candidate := range g.CandidateProbabilities() {
+			solving := g.Clone()
+			solving.options.Answer = candidate.Word
+
+			result := solving.solve()
+			want += candidate.Score * float64(result.Guesses-len(g.turns))
+			total += candidate.Score
+		}
+
+		if got := g.ExpectedGuesses(); math.Abs(got-want/total) > 1e-9 {
+			t.Errorf("ExpectedGuesses() with weights %v for %v possible answers = %v, want %v", w, len(g.dictionary),
+				got, want/total)
+		}
+	}
+
+	g, err := NewGame(GameOptions{Dictionary: ValidWords[:300]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := g.ExpectedGuesses(), estimateGuesses(300); got != want {
+		t.Errorf("ExpectedGuesses() for 300 possible answers = %v, want the estimate %v", got, want)
+	}
+
+	if _, _, err := g.Apply("moist", "ggggg"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := g.ExpectedGuesses(); got != 1 {
+		t.Errorf("ExpectedGuesses() once solved = %v, want 1", got)
+	}
+}
@@ -1,9 +1,20 @@
 // Package wordle provides a Wordle solver. See NewGame.
 package wordle
 
+import "math/rand"
+
 const (
 	wordSize = 5
 )
 
 // Verbose controls the level of information printed to the console while playing a Game.
 var Verbose = true
+
+// Answers holds the words which can be the answer to the official Wordle, in the order they're used. They're the first
+// words in ValidWords.
+var Answers = ValidWords[:2315]
+
+// RandomAnswer returns an answer from Answers chosen using r.
+func RandomAnswer(r *rand.Rand) string {
+	return Answers[r.Intn(len(Answers))]
+}
@@ -0,0 +1,96 @@
+package wordle
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+// quiet turns off Verbose and discards what's printed to stdout until the returned function is called, e.g. deferred
+// by a test which plays games.
+func quiet() func() {
+	verbose, stdout := Verbose, os.Stdout
+	Verbose = false
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err == nil {
+		os.Stdout = devNull
+	}
+
+	return func() {
+		Verbose, os.Stdout = verbose, stdout
+		if err == nil {
+			devNull.Close()
+		}
+	}
+}
+
+// captureOutput returns what f prints to stdout.
+func captureOutput(t *testing.T, f func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+
+	// Buffered so that the copy can finish even if f panics.
+	output := make(chan string, 1)
+	go func() {
+		var b bytes.Buffer
+		_, _ = io.Copy(&b, r)
+		output <- b.String()
+	}()
+
+	func() {
+		defer func() {
+			os.Stdout = stdout
+			w.Close()
+		}()
+
+		f()
+	}()
+
+	return <-output
+}
+
+// metricsFunc is a Metrics which calls itself with each observation.
+type metricsFunc func(name string, value float64)
+
+func (f metricsFunc) Observe(name string, value float64) {
+	f(name, value)
+}
+
+// Entropy is calculated from whole hint counts, so it's exactly the same however the work is shared out.
+func TestCachedFirstGuessEntropy(t *testing.T) {
+	entropy := workerPool.calculateEntropy(cachedFirstGuess, ValidWords, newDictionaryVersion())
+	if entropy != cachedFirstGuessEntropy {
+		t.Errorf("entropy of %v is %v, but %v is cached", cachedFirstGuess, entropy, cachedFirstGuessEntropy)
+	}
+
+	serial := calculateEntropySerially(cachedFirstGuess, ValidWords)
+	if serial != cachedFirstGuessEntropy {
+		t.Errorf("serial entropy of %v is %v, but %v is cached", cachedFirstGuess, serial, cachedFirstGuessEntropy)
+	}
+}
+
+func TestCachedFirstGuessIsBest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("scoring every first guess is slow")
+	}
+
+	g, err := NewGame(GameOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	best := g.BestGuesses(1)[0]
+	if best.Word != cachedFirstGuess || best.Score != cachedFirstGuessEntropy {
+		t.Errorf("best first guess is %v (%v), but %v (%v) is cached", best.Word, best.Score, cachedFirstGuess,
+			cachedFirstGuessEntropy)
+	}
+}